@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The condition types of IngressAuditStatus, one per group of checks
+const (
+	// TLSConfiguredCondition is true if the TLS blocks reference valid TLS secrets for routed hosts
+	TLSConfiguredCondition = "TLSConfigured"
+	// CertificateValidCondition is true if every TLS host serves a valid certificate covering it
+	CertificateValidCondition = "CertificateValid"
+	// RedirectEnforcedCondition is true if the ingress uses TLS or redirects HTTP traffic
+	RedirectEnforcedCondition = "RedirectEnforced"
+	// ExpiryOKCondition is true if no certificate in the TLS secrets expires soon
+	ExpiryOKCondition = "ExpiryOK"
+)
+
+// IngressAuditStatusSpec defines the audited ingress of IngressAuditStatus
+type IngressAuditStatusSpec struct {
+	// IngressName is the name of the audited ingress in the namespace of the IngressAuditStatus.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	IngressName string `json:"ingressName"`
+}
+
+// HostCertificate summarises the certificate in the TLS secret of a host
+type HostCertificate struct {
+	// Host is the TLS host of the ingress.
+	// +required
+	Host string `json:"host"`
+
+	// SecretName is the secret of the TLS block of the host, as referenced by the ingress.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// Subject is the subject of the certificate.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Issuer is the issuer of the certificate.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// DNSNames are the DNS names in the subject alternative names of the certificate.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// SerialNumber is the serial number of the certificate.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// NotBefore is the start of the validity of the certificate.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the expiry of the certificate.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// Fingerprint is the SHA-256 fingerprint of the certificate.
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// IngressAuditStatusStatus defines the audit state of the ingress
+type IngressAuditStatusStatus struct {
+	// conditions hold the result of each group of checks: TLSConfigured, CertificateValid,
+	// RedirectEnforced and ExpiryOK. A condition is Unknown if its checks did not run,
+	// e.g. because an earlier check failed.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Certificates are the parsed certificates in the TLS secrets, one per TLS host.
+	// +listType=map
+	// +listMapKey=host
+	// +optional
+	Certificates []HostCertificate `json:"certificates,omitempty"`
+
+	// LastCheckedTime is when the ingress was last audited.
+	// +optional
+	LastCheckedTime *metav1.Time `json:"lastCheckedTime,omitempty"`
+
+	// ObservedGeneration is the generation of the ingress last audited.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ias
+// +kubebuilder:printcolumn:name="TLS",type=string,JSONPath=`.status.conditions[?(@.type=="TLSConfigured")].status`
+// +kubebuilder:printcolumn:name="Certificate",type=string,JSONPath=`.status.conditions[?(@.type=="CertificateValid")].status`
+// +kubebuilder:printcolumn:name="Redirect",type=string,JSONPath=`.status.conditions[?(@.type=="RedirectEnforced")].status`
+// +kubebuilder:printcolumn:name="Expiry",type=string,JSONPath=`.status.conditions[?(@.type=="ExpiryOK")].status`
+// +kubebuilder:printcolumn:name="Last Checked",type=date,JSONPath=`.status.lastCheckedTime`
+
+// IngressAuditStatus is the Schema for the ingressauditstatuses API.
+// The reconciler keeps one per ingress, named after it and owned by it.
+type IngressAuditStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the audited ingress
+	// +required
+	Spec IngressAuditStatusSpec `json:"spec"`
+
+	// status defines the audit state of the ingress
+	// +optional
+	Status IngressAuditStatusStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// IngressAuditStatusList contains a list of IngressAuditStatus
+type IngressAuditStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []IngressAuditStatus `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IngressAuditStatus{}, &IngressAuditStatusList{})
+}
@@ -50,6 +50,16 @@ type IngressTLSLogSpec struct {
 	// +required
 	Message string `json:"message"`
 
+	// Reason is the machine readable code of the message, e.g. HostnameMismatch or DNSResolution.
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Host is the ingress host the message is about, empty if it is about the whole ingress.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Host string `json:"host,omitempty"`
+
 	// Timestamp records the generation timestamp of the log for interval control.
 	// +required
 	GenerationTimestamp *metav1.Time `json:"generationTimestamp"`
@@ -76,10 +86,27 @@ type IngressTLSLogStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// FirstSeen is when the finding was first logged, only set in upsert mode.
+	// +optional
+	FirstSeen *metav1.Time `json:"firstSeen,omitempty"`
+
+	// LastSeen is when the finding was last logged, only set in upsert mode.
+	// +optional
+	LastSeen *metav1.Time `json:"lastSeen,omitempty"`
+
+	// OccurrenceCount counts how often the finding was logged, only set in upsert mode.
+	// +optional
+	OccurrenceCount int64 `json:"occurrenceCount,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=itl
+// +kubebuilder:printcolumn:name="Level",type=string,JSONPath=`.spec.level`
+// +kubebuilder:printcolumn:name="Ingress",type=string,JSONPath=`.spec.ingressName`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // IngressTLSLog is the Schema for the ingresstlslogs API
 type IngressTLSLog struct {
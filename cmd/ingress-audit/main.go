@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// ingress-audit runs the audit of the ingresses without a cluster, e.g. in CI pipelines
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// errFindingsAboveThreshold makes the command exit non-zero without printing usage
+var errFindingsAboveThreshold = errors.New("findings at or above the severity threshold")
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		if !errors.Is(err, errFindingsAboveThreshold) {
+			_, _ = fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// newRootCommand creates the command with all subcommands
+func newRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "ingress-audit",
+		Short:         "Audit the TLS of ingresses without a cluster",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.AddCommand(newScanCommand())
+
+	return cmd
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+	"github.com/MMMMMMorty/ingress-auditor/internal/report"
+	"github.com/MMMMMMorty/ingress-auditor/internal/scan"
+)
+
+// scanOptions are the flags of the scan subcommand
+type scanOptions struct {
+	namespace                  string
+	ingressClass               string
+	allowCrossNamespaceSecrets bool
+	defaultCertificate         string
+	expiryWarning              time.Duration
+	severityThreshold          string
+	output                     string
+	policyFile                 string
+	disabledCheckers           []string
+}
+
+// newScanCommand creates the scan subcommand
+func newScanCommand() *cobra.Command {
+	o := &scanOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "scan [path...]",
+		Short: "Run the static checks on Ingress, Secret and IngressClass manifests",
+		Long: "Run the static checks of the auditor on the Ingress, Secret and IngressClass manifests " +
+			"in the files and directories, or on stdin if the path is - or omitted, e.g. helm template . | ingress-audit scan. " +
+			"The hosts are not probed. The command exits non-zero if a finding is at or above the severity threshold.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScan(o, args, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "default", "The namespace of the manifests without namespace.")
+	cmd.Flags().StringVar(&o.ingressClass, "ingress-class", "", "If set, only the ingresses of this class are scanned.")
+	cmd.Flags().BoolVar(&o.allowCrossNamespaceSecrets, "allow-cross-namespace-secrets", false,
+		"If set, a secretName in the namespace/name syntax references a secret in another namespace.")
+	cmd.Flags().StringVar(&o.defaultCertificate, "default-certificate", "",
+		"The namespace/name of the default certificate secret, used for TLS blocks without secretName.")
+	cmd.Flags().DurationVar(&o.expiryWarning, "expiry-warning", 30*24*time.Hour,
+		"Certificates expiring within this duration are reported as Warn.")
+	cmd.Flags().StringVar(&o.severityThreshold, "severity-threshold", controller.ErrLogLevel,
+		"Exit non-zero if a finding is at or above this severity: Error, Warn or Info.")
+	cmd.Flags().StringVarP(&o.output, "output", "o", report.FormatTable, "The output format: table, json, sarif or junit.")
+	cmd.Flags().StringVar(&o.policyFile, "policy-file", "", "The YAML policy file of the auditor, its rules are checked too.")
+	cmd.Flags().StringSliceVar(&o.disabledCheckers, "disabled-checkers", nil,
+		"The comma-separated names of the checkers to skip, like the disabled-checkers of the auditor.")
+
+	return cmd
+}
+
+// runScan loads the manifests, prints the findings and fails above the threshold
+func runScan(o *scanOptions, paths []string, stdin io.Reader, stdout io.Writer) error {
+	if !scan.ValidSeverity(o.severityThreshold) {
+		return fmt.Errorf("invalid --severity-threshold %q, expected Error, Warn or Info", o.severityThreshold)
+	}
+
+	if !report.ValidFormat(o.output) {
+		return fmt.Errorf("invalid --output %q, expected table, json, sarif or junit", o.output)
+	}
+
+	opts := scan.Options{
+		StaticOptions: controller.StaticOptions{
+			AllowCrossNamespaceSecrets: o.allowCrossNamespaceSecrets,
+			ExpiryWarning:              o.expiryWarning,
+			Now:                        time.Now(),
+		},
+		Namespace:    o.namespace,
+		IngressClass: o.ingressClass,
+	}
+
+	auditPolicy := &policy.Policy{}
+	if o.policyFile != "" {
+		var err error
+		if auditPolicy, err = policy.Load(o.policyFile); err != nil {
+			return err
+		}
+	}
+
+	checkers, err := controller.NewPolicyCheckerRegistry(auditPolicy.Rules, o.disabledCheckers)
+	if err != nil {
+		return err
+	}
+	opts.Checkers = checkers
+
+	if o.defaultCertificate != "" {
+		key, err := controller.ParseSecretReference(o.defaultCertificate)
+		if err != nil {
+			return err
+		}
+		opts.DefaultCertificate = &key
+	}
+
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	manifests, err := scan.LoadPaths(paths, o.namespace, stdin)
+	if err != nil {
+		return err
+	}
+
+	result := scan.Scan(manifests, opts)
+
+	if o.output == report.FormatTable && len(result.Findings) == 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "No findings in %d ingresses.\n", len(result.Ingresses))
+	} else if err := report.Write(stdout, o.output, result); err != nil {
+		return err
+	}
+
+	if scan.Exceeds(result.Findings, o.severityThreshold) {
+		return errFindingsAboveThreshold
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+)
+
+// newExemptCommand creates the exempt subcommand
+func newExemptCommand(o *options) *cobra.Command {
+	var until string
+	var clearExemption bool
+
+	cmd := &cobra.Command{
+		Use:   "exempt <ingress> --until <time>",
+		Short: "Exempt an ingress from the audit until a time",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var untilTime *time.Time
+			if !clearExemption {
+				if until == "" {
+					return fmt.Errorf("--until or --clear is required")
+				}
+
+				t, err := parseUntil(until, time.Now())
+				if err != nil {
+					return err
+				}
+				untilTime = &t
+			}
+
+			c, namespace, err := o.client()
+			if err != nil {
+				return err
+			}
+			return runExempt(cmd.Context(), c, namespace, args[0], untilTime, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&until, "until", "", "The RFC 3339 time or the duration from now (e.g. 72h) the exemption ends.")
+	cmd.Flags().BoolVar(&clearExemption, "clear", false, "Remove the exemption.")
+
+	return cmd
+}
+
+// runExempt annotates the ingress with the end of the exemption, or removes the annotation if until is nil
+func runExempt(ctx context.Context, c client.Client, namespace, ingressName string, until *time.Time, out io.Writer) error {
+	ingress := &networkingv1.Ingress{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ingressName}, ingress); err != nil {
+		return fmt.Errorf("failed to get ingress: %w", err)
+	}
+
+	patch := client.MergeFrom(ingress.DeepCopy())
+	if until == nil {
+		delete(ingress.Annotations, controller.ExemptUntilAnnotation)
+	} else {
+		if ingress.Annotations == nil {
+			ingress.Annotations = map[string]string{}
+		}
+		ingress.Annotations[controller.ExemptUntilAnnotation] = until.UTC().Format(time.RFC3339)
+	}
+
+	if err := c.Patch(ctx, ingress, patch); err != nil {
+		return fmt.Errorf("failed to annotate ingress: %w", err)
+	}
+
+	if until == nil {
+		_, _ = fmt.Fprintf(out, "ingress %s/%s is no longer exempted\n", namespace, ingressName)
+	} else {
+		_, _ = fmt.Fprintf(out, "ingress %s/%s is exempted until %s\n", namespace, ingressName, until.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// parseUntil parses an RFC 3339 time or a duration from now
+func parseUntil(until string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(until); err == nil {
+		return now.Add(d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --until %q, expected an RFC 3339 time or a duration", until)
+	}
+
+	return t, nil
+}
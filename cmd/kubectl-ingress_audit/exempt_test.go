@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+)
+
+var _ = Describe("exempt", func() {
+	ctx := context.Background()
+	now := time.Date(2025, 12, 12, 8, 0, 0, 0, time.UTC)
+
+	DescribeTable("parseUntil",
+		func(until string, expected time.Time) {
+			t, err := parseUntil(until, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Equal(expected)).To(BeTrue(), t.String())
+		},
+		Entry("a duration from now", "72h", now.Add(72*time.Hour)),
+		Entry("an RFC 3339 time", "2026-01-01T00:00:00Z", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("an RFC 3339 time with offset", "2026-01-01T02:00:00+02:00", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	It("should reject an invalid until", func() {
+		_, err := parseUntil("tomorrow", now)
+		Expect(err).To(MatchError(ContainSubstring(`invalid --until "tomorrow"`)))
+	})
+
+	It("should set and clear the exemption of the ingress", func() {
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"}}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ingress).Build()
+		key := types.NamespacedName{Namespace: "ns", Name: "web"}
+
+		out := &bytes.Buffer{}
+		until := now.Add(time.Hour)
+		Expect(runExempt(ctx, c, "ns", "web", &until, out)).To(Succeed())
+		Expect(out.String()).To(Equal("ingress ns/web is exempted until 2025-12-12T09:00:00Z\n"))
+
+		Expect(c.Get(ctx, key, ingress)).To(Succeed())
+		Expect(ingress.Annotations).To(HaveKeyWithValue(controller.ExemptUntilAnnotation, "2025-12-12T09:00:00Z"))
+
+		Expect(runExempt(ctx, c, "ns", "web", nil, out)).To(Succeed())
+		Expect(c.Get(ctx, key, ingress)).To(Succeed())
+		Expect(ingress.Annotations).NotTo(HaveKey(controller.ExemptUntilAnnotation))
+	})
+
+	It("should fail for a missing ingress", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		Expect(runExempt(ctx, c, "ns", "web", nil, &bytes.Buffer{})).To(MatchError(ContainSubstring("failed to get ingress")))
+	})
+})
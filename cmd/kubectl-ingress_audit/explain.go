@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+)
+
+// newExplainCommand creates the explain subcommand
+func newExplainCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain [reason]",
+		Short: "Explain a reason of the ingress TLS logs, or list all reasons",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+				_, _ = fmt.Fprintln(w, "REASON\tCATEGORY")
+				for _, reason := range controller.Reasons() {
+					_, _ = fmt.Fprintf(w, "%s\t%s\n", reason.Code, reason.Category)
+				}
+				return w.Flush()
+			}
+
+			reason, ok := controller.ReasonByCode(args[0])
+			if !ok {
+				return fmt.Errorf("unknown reason %q, run explain without arguments to list all reasons", args[0])
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "REASON:    %s\nCATEGORY:  %s\n\n%s\n", reason.Code, reason.Category, reason.Description)
+			return nil
+		},
+	}
+}
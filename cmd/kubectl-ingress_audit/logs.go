@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+)
+
+// newLogsCommand creates the logs subcommand
+func newLogsCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logs <ingress>",
+		Short: "Show the history of the ingress TLS logs of an ingress",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, namespace, err := o.client()
+			if err != nil {
+				return err
+			}
+
+			return runLogs(cmd.Context(), c, namespace, args[0], cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+}
+
+// runLogs prints the logs of the ingress sorted by their GenerationTimestamp.
+// The logs are selected by their IngressLabel, logs created before the labels were introduced are not shown.
+// The label of a long name is truncated and hashed, see controller.IngressLabelValue.
+func runLogs(ctx context.Context, c client.Client, namespace, ingressName string, out, errOut io.Writer) error {
+	logs := &ingressauditv1alpha1.IngressTLSLogList{}
+	if err := c.List(ctx, logs, client.InNamespace(namespace), client.MatchingLabels{controller.IngressLabel: controller.IngressLabelValue(ingressName)}); err != nil {
+		return fmt.Errorf("failed to list ingress TLS logs: %w", err)
+	}
+
+	history := make([]*ingressauditv1alpha1.IngressTLSLog, 0, len(logs.Items))
+	for i := range logs.Items {
+		history = append(history, &logs.Items[i])
+	}
+
+	if len(history) == 0 {
+		_, _ = fmt.Fprintf(errOut, "No ingress TLS logs found for ingress %s/%s.\n", namespace, ingressName)
+		return nil
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return generationTime(history[i]).Before(generationTime(history[j]))
+	})
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TIME\tSEVERITY\tREASON\tHOST\tMESSAGE")
+	for _, log := range history {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			generationTime(log).Format(time.RFC3339), log.Spec.LogLevel, log.Spec.Reason, log.Spec.Host, log.Spec.Message)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+)
+
+var _ = Describe("logs", func() {
+	ctx := context.Background()
+	now := time.Date(2025, 12, 12, 8, 0, 0, 0, time.UTC)
+
+	newLog := func(name, ingressName, reason string, generated time.Time) *ingressauditv1alpha1.IngressTLSLog {
+		return &ingressauditv1alpha1.IngressTLSLog{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				Labels:    map[string]string{controller.IngressLabel: controller.IngressLabelValue(ingressName)},
+			},
+			Spec: ingressauditv1alpha1.IngressTLSLogSpec{
+				NameSpace:           "ns",
+				IngressName:         ingressName,
+				Reason:              reason,
+				LogLevel:            controller.ErrLogLevel,
+				Message:             reason,
+				GenerationTimestamp: &metav1.Time{Time: generated},
+			},
+		}
+	}
+
+	It("should print the labeled logs of the ingress oldest first", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newLog("web-2", "web", "CertificateExpired", now),
+			newLog("web-1", "web", "HostnameMismatch", now.Add(-time.Hour)),
+			newLog("other-1", "other", "HostnameMismatch", now),
+		).Build()
+
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		Expect(runLogs(ctx, c, "ns", "web", out, errOut)).To(Succeed())
+		Expect(errOut.String()).To(BeEmpty())
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(3))
+		Expect(strings.Fields(lines[1])).To(Equal([]string{"2025-12-12T07:00:00Z", "Error", "HostnameMismatch", "HostnameMismatch"}))
+		Expect(strings.Fields(lines[2])).To(Equal([]string{"2025-12-12T08:00:00Z", "Error", "CertificateExpired", "CertificateExpired"}))
+	})
+
+	It("should select the logs of ingresses with long names by the hashed label", func() {
+		name := strings.Repeat("web", 30)
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newLog("long-1", name, "HostnameMismatch", now)).Build()
+
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		Expect(runLogs(ctx, c, "ns", name, out, errOut)).To(Succeed())
+		Expect(errOut.String()).To(BeEmpty())
+		Expect(strings.Split(strings.TrimSpace(out.String()), "\n")).To(HaveLen(2))
+	})
+
+	It("should tell if the ingress has no logs", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newLog("other-1", "other", "HostnameMismatch", now)).Build()
+
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		Expect(runLogs(ctx, c, "ns", "web", out, errOut)).To(Succeed())
+		Expect(out.String()).To(BeEmpty())
+		Expect(errOut.String()).To(Equal("No ingress TLS logs found for ingress ns/web.\n"))
+	})
+})
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/report"
+)
+
+// newReportCommand creates the report subcommand
+func newReportCommand(o *options) *cobra.Command {
+	var format, outputFile string
+	var staleAfter time.Duration
+	var allowCrossNamespaceSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Generate an HTML or Markdown compliance report of the ingresses",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			if format != report.FormatHTML && format != report.FormatMarkdown {
+				return fmt.Errorf("invalid --format %q, expected html or markdown", format)
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if outputFile != "" {
+				f, err := os.Create(outputFile)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					// The report is incomplete if the file cannot be flushed
+					if closeErr := f.Close(); err == nil {
+						err = closeErr
+					}
+				}()
+				w = f
+			}
+
+			return runReport(cmd.Context(), o, w, format, report.ComplianceOptions{
+				StaleAfter:                 staleAfter,
+				AllowCrossNamespaceSecrets: allowCrossNamespaceSecrets,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "Report the ingresses of all namespaces.")
+	cmd.Flags().StringVar(&format, "format", report.FormatHTML, "The format of the report: html or markdown.")
+	cmd.Flags().StringVarP(&outputFile, "output-file", "f", "", "The file the report is written to, in default stdout.")
+	cmd.Flags().DurationVar(&staleAfter, "stale-after", 25*time.Hour,
+		"The findings of ingresses without IngressAuditStatus are considered resolved if they are not logged again "+
+			"within this duration. It must exceed the interval and the maximum re-notification interval of the auditor.")
+	cmd.Flags().BoolVar(&allowCrossNamespaceSecrets, "allow-cross-namespace-secrets", false,
+		"Read secretName values like namespace/name from that namespace, as the auditor with the same flag does.")
+
+	return cmd
+}
+
+// runReport builds the compliance report from the ingresses, their audit statuses, logs and TLS secrets.
+// The audit statuses and the time of the report are set in opts.
+func runReport(ctx context.Context, o *options, w io.Writer, format string, opts report.ComplianceOptions) error {
+	c, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+
+	var listOpts []client.ListOption
+	if !o.allNamespaces {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+
+	ingresses := &networkingv1.IngressList{}
+	if err := c.List(ctx, ingresses, listOpts...); err != nil {
+		return fmt.Errorf("failed to list ingresses: %w", err)
+	}
+
+	statuses := &ingressauditv1alpha1.IngressAuditStatusList{}
+	if err := c.List(ctx, statuses, listOpts...); err != nil {
+		return fmt.Errorf("failed to list ingress audit statuses: %w", err)
+	}
+
+	logs := &ingressauditv1alpha1.IngressTLSLogList{}
+	if err := c.List(ctx, logs, listOpts...); err != nil {
+		return fmt.Errorf("failed to list ingress TLS logs: %w", err)
+	}
+
+	secrets := func(key types.NamespacedName) (*v1.Secret, bool) {
+		secret := &v1.Secret{}
+		if err := c.Get(ctx, key, secret); err != nil {
+			return nil, false
+		}
+		return secret, true
+	}
+
+	opts.AuditStatuses = statuses.Items
+	opts.Now = time.Now()
+	compliance := report.BuildCompliance(ingresses.Items, logs.Items, secrets, opts)
+
+	return report.WriteCompliance(w, format, compliance)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/report"
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+// newStatusCommand creates the status subcommand
+func newStatusCommand(o *options) *cobra.Command {
+	var allowCrossNamespaceSecrets bool
+	var staleAfter time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the current finding, severity and certificate expiry of each ingress",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			c, namespace, err := o.client()
+			if err != nil {
+				return err
+			}
+
+			return runStatus(cmd.Context(), c, statusOptions{
+				namespace:                  namespace,
+				allNamespaces:              o.allNamespaces,
+				allowCrossNamespaceSecrets: allowCrossNamespaceSecrets,
+				staleAfter:                 staleAfter,
+			}, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "Show the ingresses of all namespaces.")
+	cmd.Flags().BoolVar(&allowCrossNamespaceSecrets, "allow-cross-namespace-secrets", false,
+		"Read secretName values like namespace/name from that namespace, as the auditor with the same flag does. "+
+			"It is only used for ingresses without an IngressAuditStatus.")
+	cmd.Flags().DurationVar(&staleAfter, "stale-after", 25*time.Hour,
+		"The findings estimated from the logs, of ingresses without IngressAuditStatus and of custom rules, are considered "+
+			"resolved if they are not logged again within this duration. It must exceed the interval and the maximum "+
+			"re-notification interval of the auditor.")
+
+	return cmd
+}
+
+// statusOptions select the ingresses of the status subcommand
+type statusOptions struct {
+	// namespace is the namespace of the ingresses, unless allNamespaces is set
+	namespace     string
+	allNamespaces bool
+	// allowCrossNamespaceSecrets resolves secretName values like namespace/name, see the auditor flag
+	allowCrossNamespaceSecrets bool
+	// staleAfter closes the findings estimated from the logs if they are not logged again, see report.ComplianceOptions
+	staleAfter time.Duration
+}
+
+// runStatus prints the table of the ingresses.
+// The current finding and the certificates are read from the IngressAuditStatus of the ingress, the severity
+// from the latest ingress TLS log of the finding. The findings of ingresses without an IngressAuditStatus and of
+// the custom rules are estimated from the logs, like in the compliance report.
+func runStatus(ctx context.Context, c client.Client, o statusOptions, out, errOut io.Writer) error {
+	var listOpts []client.ListOption
+	if !o.allNamespaces {
+		listOpts = append(listOpts, client.InNamespace(o.namespace))
+	}
+
+	ingresses := &networkingv1.IngressList{}
+	if err := c.List(ctx, ingresses, listOpts...); err != nil {
+		return fmt.Errorf("failed to list ingresses: %w", err)
+	}
+
+	statusList := &ingressauditv1alpha1.IngressAuditStatusList{}
+	if err := c.List(ctx, statusList, listOpts...); err != nil {
+		return fmt.Errorf("failed to list ingress audit statuses: %w", err)
+	}
+
+	statuses := map[types.NamespacedName]*ingressauditv1alpha1.IngressAuditStatus{}
+	for i := range statusList.Items {
+		status := &statusList.Items[i]
+		statuses[types.NamespacedName{Namespace: status.Namespace, Name: status.Spec.IngressName}] = status
+	}
+
+	logs := &ingressauditv1alpha1.IngressTLSLogList{}
+	if err := c.List(ctx, logs, listOpts...); err != nil {
+		return fmt.Errorf("failed to list ingress TLS logs: %w", err)
+	}
+
+	now := time.Now()
+	findings := report.NewFindings(logs.Items, report.ComplianceOptions{
+		AuditStatuses: statusList.Items,
+		StaleAfter:    o.staleAfter,
+		Now:           now,
+	})
+
+	sort.Slice(ingresses.Items, func(i, j int) bool {
+		a, b := ingresses.Items[i], ingresses.Items[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	if len(ingresses.Items) != 0 && len(statuses) == 0 {
+		_, _ = fmt.Fprintf(errOut, "No IngressAuditStatus found, the findings are estimated from the ingress TLS logs of the last %s. "+
+			"Run the auditor with --audit-status for the current findings.\n", duration.HumanDuration(o.staleAfter))
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if o.allNamespaces {
+		_, _ = fmt.Fprint(w, "NAMESPACE\t")
+	}
+	_, _ = fmt.Fprintln(w, "NAME\tFINDING\tSEVERITY\tLAST SEEN\tCERT EXPIRY\tEXEMPT UNTIL")
+
+	for i := range ingresses.Items {
+		ingress := &ingresses.Items[i]
+		key := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+
+		finding, severity, lastSeen := "<none>", "", ""
+		if current, _ := findings.Of(key); current != nil {
+			finding, severity = current.Reason, current.Severity
+			lastSeen = duration.HumanDuration(now.Sub(current.LastSeen)) + " ago"
+		} else if findings.NotAudited(key) {
+			finding = "<not audited>"
+		}
+
+		var expiry string
+		if status, ok := statuses[key]; ok {
+			expiry = formatExpiry(statusExpiry(status))
+		} else {
+			expiry = formatExpiry(secretExpiry(ctx, c, ingress, o.allowCrossNamespaceSecrets))
+		}
+
+		exempt := ingress.Annotations[controller.ExemptUntilAnnotation]
+
+		if o.allNamespaces {
+			_, _ = fmt.Fprintf(w, "%s\t", ingress.Namespace)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", ingress.Name, finding, severity, lastSeen, expiry, exempt)
+	}
+
+	return w.Flush()
+}
+
+// statusExpiry returns the earliest expiry of the certificates in the IngressAuditStatus
+func statusExpiry(status *ingressauditv1alpha1.IngressAuditStatus) time.Time {
+	var earliest time.Time
+	for _, certificate := range status.Status.Certificates {
+		if certificate.NotAfter != nil && (earliest.IsZero() || certificate.NotAfter.Time.Before(earliest)) {
+			earliest = certificate.NotAfter.Time
+		}
+	}
+
+	return earliest
+}
+
+// secretExpiry returns the earliest expiry of the certificates in the TLS secrets of the ingress.
+// A secretName like namespace/name is only read from that namespace if allowCrossNamespace is set.
+func secretExpiry(ctx context.Context, c client.Client, ingress *networkingv1.Ingress, allowCrossNamespace bool) time.Time {
+	var earliest time.Time
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: ingress.Namespace, Name: tls.SecretName}
+		if allowCrossNamespace && strings.Contains(tls.SecretName, "/") {
+			ref, err := controller.ParseSecretReference(tls.SecretName)
+			if err != nil {
+				continue
+			}
+			key = ref
+		}
+
+		secret := &v1.Secret{}
+		if err := c.Get(ctx, key, secret); err != nil {
+			continue
+		}
+
+		cert, err := utils.ParseCertificate(secret.Data[v1.TLSCertKey])
+		if err != nil {
+			continue
+		}
+
+		if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+			earliest = cert.NotAfter
+		}
+	}
+
+	return earliest
+}
+
+// formatExpiry formats the expiry of a certificate, it is empty if no certificate was read
+func formatExpiry(expiry time.Time) string {
+	if expiry.IsZero() {
+		return ""
+	}
+
+	if time.Now().After(expiry) {
+		return expiry.Format(time.DateOnly) + " (expired)"
+	}
+
+	return expiry.Format(time.DateOnly)
+}
+
+// generationTime returns the GenerationTimestamp of the log, or its creation if it is not set
+func generationTime(log *ingressauditv1alpha1.IngressTLSLog) time.Time {
+	if log.Spec.GenerationTimestamp != nil {
+		return log.Spec.GenerationTimestamp.Time
+	}
+
+	return log.CreationTimestamp.Time
+}
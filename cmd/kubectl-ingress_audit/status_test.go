@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+)
+
+var _ = Describe("status", func() {
+	ctx := context.Background()
+
+	newCertificate := func(notAfter time.Time) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "foo.com"}, NotAfter: notAfter}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+
+	newIngress := func(name, secretName string) *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec:       networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{SecretName: secretName, Hosts: []string{"foo.com"}}}},
+		}
+	}
+
+	newStatus := func(name string, conditions ...metav1.Condition) *ingressauditv1alpha1.IngressAuditStatus {
+		checked := metav1.NewTime(time.Now().Add(-time.Hour))
+		return &ingressauditv1alpha1.IngressAuditStatus{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec:       ingressauditv1alpha1.IngressAuditStatusSpec{IngressName: name},
+			Status: ingressauditv1alpha1.IngressAuditStatusStatus{
+				Conditions:      conditions,
+				LastCheckedTime: &checked,
+				Certificates: []ingressauditv1alpha1.HostCertificate{
+					{Host: "foo.com", NotAfter: &metav1.Time{Time: time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)}},
+				},
+			},
+		}
+	}
+
+	newLog := func(name, ingressName, reason, level string, generated time.Time) *ingressauditv1alpha1.IngressTLSLog {
+		return &ingressauditv1alpha1.IngressTLSLog{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec: ingressauditv1alpha1.IngressTLSLogSpec{
+				NameSpace:           "ns",
+				IngressName:         ingressName,
+				Reason:              reason,
+				LogLevel:            level,
+				GenerationTimestamp: &metav1.Time{Time: generated},
+			},
+		}
+	}
+
+	runTable := func(c client.Client, o statusOptions) ([]string, string) {
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		Expect(runStatus(ctx, c, o, out, errOut)).To(Succeed())
+
+		var rows []string
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n")[1:] {
+			rows = append(rows, strings.Join(strings.Fields(line), " "))
+		}
+		return rows, errOut.String()
+	}
+
+	It("should show the finding of the IngressAuditStatus instead of the latest log", func() {
+		resolved := newStatus("resolved",
+			metav1.Condition{Type: ingressauditv1alpha1.TLSConfiguredCondition, Status: metav1.ConditionTrue, Reason: controller.ChecksPassedReason},
+			metav1.Condition{Type: ingressauditv1alpha1.CertificateValidCondition, Status: metav1.ConditionTrue, Reason: controller.ChecksPassedReason},
+		)
+		failing := newStatus("failing",
+			metav1.Condition{Type: ingressauditv1alpha1.TLSConfiguredCondition, Status: metav1.ConditionTrue, Reason: controller.ChecksPassedReason},
+			metav1.Condition{Type: ingressauditv1alpha1.CertificateValidCondition, Status: metav1.ConditionFalse, Reason: "CertificateExpired"},
+		)
+		now := time.Now()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newIngress("failing", "tls"), newIngress("resolved", "tls"), resolved, failing,
+			// The resolved ingress still has its old log, the failing one was escalated
+			newLog("resolved-1", "resolved", "HostnameMismatch", "Error", now.Add(-time.Hour)),
+			newLog("failing-1", "failing", "CertificateExpired", "Warn", now.Add(-2*time.Hour)),
+			newLog("failing-2", "failing", "CertificateExpired", "Error", now.Add(-time.Hour)),
+			newLog("failing-3", "failing", "HostnameMismatch", "Warn", now),
+		).Build()
+
+		rows, errOut := runTable(c, statusOptions{namespace: "ns"})
+		Expect(errOut).To(BeEmpty())
+		Expect(rows).To(Equal([]string{
+			"failing CertificateExpired Error 60m ago 2030-01-02",
+			"resolved <none> 2030-01-02",
+		}))
+	})
+
+	It("should only read cross-namespace secrets if they are allowed", func() {
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "tls"},
+			Data:       map[string][]byte{v1.TLSCertKey: newCertificate(time.Date(2031, 3, 4, 0, 0, 0, 0, time.UTC))},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newIngress("web", "certs/tls"), secret).Build()
+
+		rows, errOut := runTable(c, statusOptions{namespace: "ns"})
+		Expect(errOut).To(ContainSubstring("--audit-status"))
+		Expect(rows).To(Equal([]string{"web <none>"}))
+
+		rows, _ = runTable(c, statusOptions{namespace: "ns", allowCrossNamespaceSecrets: true})
+		Expect(rows).To(Equal([]string{"web <none> 2031-03-04"}))
+	})
+
+	It("should estimate the findings from the logs without IngressAuditStatus", func() {
+		now := time.Now()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newIngress("failing", ""), newIngress("resolved", ""),
+			newLog("failing-1", "failing", "HTTPRedirectMissing", "Warn", now.Add(-2*time.Hour)),
+			newLog("resolved-1", "resolved", "HTTPRedirectMissing", "Error", now.Add(-48*time.Hour)),
+		).Build()
+
+		rows, errOut := runTable(c, statusOptions{namespace: "ns", staleAfter: 25 * time.Hour})
+		Expect(errOut).To(ContainSubstring("estimated from the ingress TLS logs of the last 25h"))
+		Expect(rows).To(Equal([]string{
+			"failing HTTPRedirectMissing Warn 120m ago",
+			"resolved <none>",
+		}))
+	})
+
+	It("should show the findings of the custom rules and the ingresses not audited yet", func() {
+		passed := newStatus("web",
+			metav1.Condition{Type: ingressauditv1alpha1.TLSConfiguredCondition, Status: metav1.ConditionTrue, Reason: controller.ChecksPassedReason},
+			metav1.Condition{Type: ingressauditv1alpha1.CertificateValidCondition, Status: metav1.ConditionTrue, Reason: controller.ChecksPassedReason},
+		)
+		now := time.Now()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newIngress("web", "tls"), newIngress("new", "tls"), passed,
+			// The custom rule has no condition, the built-in finding was fixed
+			newLog("web-1", "web", "HostnameMismatch", "Error", now.Add(-3*time.Hour)),
+			newLog("web-2", "web", "ProdIngressClass", "Warn", now.Add(-time.Hour)),
+		).Build()
+
+		rows, errOut := runTable(c, statusOptions{namespace: "ns", staleAfter: 25 * time.Hour})
+		Expect(errOut).To(BeEmpty())
+		Expect(rows).To(Equal([]string{
+			"new <not audited>",
+			"web ProdIngressClass Warn 60m ago 2030-01-02",
+		}))
+	})
+})
@@ -17,27 +17,41 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"time"
+	// Embed the time zones of the quiet hours, the distroless image has none
+	_ "time/tzdata"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/api"
 	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/notify"
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+	"github.com/MMMMMMorty/ingress-auditor/internal/prober"
 	"github.com/MMMMMMorty/ingress-auditor/internal/store"
 	// +kubebuilder:scaffold:imports
 )
@@ -65,6 +79,31 @@ func main() {
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
 	var intervalSeconds int
+	var probePort int
+	var probeAddress string
+	var probeUseLoadBalancer bool
+	var probeWorkers int
+	var probeRate float64
+	var probeBurst int
+	var probeCacheTTLSeconds int
+	var maxConcurrentReconciles int
+	var allowCrossNamespaceSecrets bool
+	var defaultCertificate string
+	var webhookURLs string
+	var webhookFormat, webhookTemplateFile, webhookSecretFile string
+	var webhookMaxRetries int
+	var cloudEventsURLs, cloudEventsMode string
+	var clusterName string
+	var policyFile string
+	var auditLogPath string
+	var auditLogMaxSizeMB, auditLogMaxBackups int
+	var enableAPI bool
+	var logMode string
+	var disabledCheckers string
+	var dryRun bool
+	var metadataOnlySecrets, certificateOnly bool
+	var auditStatus, annotateIngresses bool
+	var expiryWarning time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -83,6 +122,71 @@ func main() {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.IntVar(&intervalSeconds, "interval-second", 3600, "After each interval, ingress TLS logs will be regenerated.")
+	flag.IntVar(&probePort, "probe-port", 443, "The port dialed when verifying the TLS of ingress hosts.")
+	flag.StringVar(&probeAddress, "probe-address", "",
+		"If set, the TLS verification dials this address (e.g. the ingress controller service) "+
+			"instead of the ingress host. The host is still sent as SNI. A port in the address replaces probe-port.")
+	flag.BoolVar(&probeUseLoadBalancer, "probe-use-loadbalancer-status", false,
+		"If set, the TLS verification dials the IP or hostname in the ingress status.loadBalancer "+
+			"instead of the ingress host. The host is still sent as SNI.")
+	flag.IntVar(&probeWorkers, "probe-workers", 10, "The maximum number of TLS verifications running at the same time.")
+	flag.Float64Var(&probeRate, "probe-rate", 1,
+		"The number of TLS verifications per second allowed towards the same destination. Use 0 to disable the limit.")
+	flag.IntVar(&probeBurst, "probe-burst", 1, "The number of TLS verifications allowed at once towards the same destination.")
+	flag.IntVar(&probeCacheTTLSeconds, "probe-cache-ttl-second", 60,
+		"The TLS verification result of a host is reused for this many seconds. Use 0 to disable the cache.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The maximum number of ingresses reconciled at the same time.")
+	flag.BoolVar(&allowCrossNamespaceSecrets, "allow-cross-namespace-secrets", false,
+		"If set, a secretName in the namespace/name syntax references a secret in another namespace.")
+	flag.StringVar(&defaultCertificate, "default-certificate", "",
+		"The namespace/name of the default certificate secret of the ingress controller. "+
+			"If set, TLS blocks without secretName are verified with it instead of being logged.")
+	flag.StringVar(&webhookURLs, "notification-webhook-urls", "",
+		"Comma-separated HTTP endpoints every new ingress TLS log is POSTed to.")
+	flag.StringVar(&webhookFormat, "notification-webhook-format", notify.FormatGeneric,
+		"The payload format of the webhooks: generic, slack or teams.")
+	flag.StringVar(&webhookTemplateFile, "notification-webhook-template-file", "",
+		"A Go template file rendering the webhook payload, overriding notification-webhook-format.")
+	flag.StringVar(&webhookSecretFile, "notification-webhook-hmac-secret-file", "",
+		"If set, the webhook payloads are signed with the HMAC-SHA256 of the secret in this file.")
+	flag.IntVar(&webhookMaxRetries, "notification-webhook-max-retries", 3,
+		"The number of retries with exponential backoff after a webhook failed.")
+	flag.StringVar(&cloudEventsURLs, "notification-cloudevents-urls", "",
+		"Comma-separated CloudEvents brokers every new and resolved ingress TLS log is sent to.")
+	flag.StringVar(&cloudEventsMode, "notification-cloudevents-mode", notify.CloudEventsStructured,
+		"The CloudEvents HTTP mode of notification-cloudevents-urls: structured or binary.")
+	flag.StringVar(&clusterName, "cluster-name", "", "The name of the cluster, identifying this auditor in notifications.")
+	flag.StringVar(&policyFile, "policy-file", "", "The YAML file configuring the audit policy.")
+	flag.StringVar(&auditLogPath, "audit-log-path", "",
+		"If set, every new and resolved ingress TLS log is written as one JSON line to this file, or to stdout if it is -.")
+	flag.IntVar(&auditLogMaxSizeMB, "audit-log-max-size-mb", 100,
+		"The size in megabytes after which the audit log file is rotated. Use 0 to disable the rotation.")
+	flag.IntVar(&auditLogMaxBackups, "audit-log-max-backups", 5, "The number of rotated audit log files kept.")
+	flag.BoolVar(&enableAPI, "enable-api", false,
+		"If set, the metrics server also serves the read-only JSON API of the audit state under /api/v1/.")
+	flag.StringVar(&logMode, "log-mode", controller.LogModeAppend,
+		"How ingress TLS logs are written: append creates a new log each time, "+
+			"upsert keeps one log per ingress, host and reason and updates it in place.")
+	flag.StringVar(&disabledCheckers, "disabled-checkers", "",
+		"A comma-separated list of checkers the reconciler skips, e.g. tls-handshake,http-redirect.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, all checks run but no ingress TLS logs are created and no notifications are sent. "+
+			"The findings are logged, exported as metrics and summarised under /api/v1/summary with enable-api.")
+	flag.BoolVar(&metadataOnlySecrets, "metadata-only-secrets", false,
+		"If set, only the metadata of the secrets is cached and the secrets referenced by the ingresses "+
+			"are read from the API server when they are checked, so no private key is held in the cache.")
+	flag.BoolVar(&certificateOnly, "certificate-only", false,
+		"If set, the tls.key of the secrets is never used and dropped once a secret is read, "+
+			"the secrets only need to hold a tls.crt. It implies metadata-only-secrets, so no key is cached, "+
+			"but the API server still sends the key with each secret read.")
+	flag.BoolVar(&auditStatus, "audit-status", false,
+		"If set, an IngressAuditStatus with the conditions and certificates of each ingress is kept in sync on every reconcile.")
+	flag.DurationVar(&expiryWarning, "expiry-warning", 30*24*time.Hour,
+		"The ExpiryOK condition of the IngressAuditStatus is false for certificates expiring within this duration.")
+	flag.BoolVar(&annotateIngresses, "annotate-ingresses", false,
+		"If set, the audit summary is written back to the ingresses as ingress-audit.morty.dev/* annotations "+
+			"with server-side apply, no other field of the ingresses is changed.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -158,8 +262,169 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
+	// The port of the probe address overrides probe-port, as the address is dialed for every host
+	probeAddress, addressPort, err := controller.ParseProbeAddress(probeAddress)
+	if err != nil {
+		setupLog.Error(err, "unable to parse the probe address")
+		os.Exit(1)
+	}
+	if addressPort != 0 {
+		probePortSet := false
+		flag.Visit(func(f *flag.Flag) { probePortSet = probePortSet || f.Name == "probe-port" })
+		if probePortSet && probePort != addressPort {
+			setupLog.Error(fmt.Errorf("probe-port %d conflicts with the port of probe-address %d", probePort, addressPort),
+				"unable to parse the probe address")
+			os.Exit(1)
+		}
+		probePort = addressPort
+	}
+
+	if logMode != controller.LogModeAppend && logMode != controller.LogModeUpsert {
+		setupLog.Error(fmt.Errorf("unknown log mode %q", logMode), "unable to parse the log mode")
+		os.Exit(1)
+	}
+
+	var defaultCertificateKey *types.NamespacedName
+	if defaultCertificate != "" {
+		key, err := controller.ParseSecretReference(defaultCertificate)
+		if err != nil {
+			setupLog.Error(err, "unable to parse the default certificate")
+			os.Exit(1)
+		}
+		defaultCertificateKey = &key
+	}
+
+	auditPolicy := &policy.Policy{}
+	if policyFile != "" {
+		var err error
+		auditPolicy, err = policy.Load(policyFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load the policy")
+			os.Exit(1)
+		}
+	}
+
+	// The custom rules of the policy run after the built-in checkers
+	var disabled []string
+	if disabledCheckers != "" {
+		for _, name := range strings.Split(disabledCheckers, ",") {
+			disabled = append(disabled, strings.TrimSpace(name))
+		}
+	}
+	checkers, err := controller.NewPolicyCheckerRegistry(auditPolicy.Rules, disabled)
+	if err != nil {
+		setupLog.Error(err, "unable to create the checkers")
+		os.Exit(1)
+	}
+
+	var sinks []notify.Sink
+	if webhookURLs != "" {
+		webhookTemplate, err := notify.ParseTemplate(webhookFormat, webhookTemplateFile)
+		if err != nil {
+			setupLog.Error(err, "unable to parse the webhook template")
+			os.Exit(1)
+		}
+
+		var webhookSecret []byte
+		if webhookSecretFile != "" {
+			webhookSecret, err = os.ReadFile(webhookSecretFile)
+			if err != nil {
+				setupLog.Error(err, "unable to read the webhook HMAC secret")
+				os.Exit(1)
+			}
+			webhookSecret = bytes.TrimSpace(webhookSecret)
+		}
+
+		for _, url := range strings.Split(webhookURLs, ",") {
+			sinks = append(sinks, notify.NewWebhookSink(notify.WebhookOptions{
+				URL:        strings.TrimSpace(url),
+				Template:   webhookTemplate,
+				Secret:     webhookSecret,
+				MaxRetries: webhookMaxRetries,
+				Backoff:    time.Second,
+				Client:     &http.Client{Timeout: 10 * time.Second},
+			}))
+		}
+	}
+
+	cloudEventsTargets := auditPolicy.Notifications.CloudEvents
+	if cloudEventsURLs != "" {
+		for _, url := range strings.Split(cloudEventsURLs, ",") {
+			cloudEventsTargets = append(cloudEventsTargets, policy.CloudEventsTarget{URL: strings.TrimSpace(url), Mode: cloudEventsMode})
+		}
+	}
+
+	for _, target := range cloudEventsTargets {
+		sink, err := notify.NewCloudEventsSink(notify.CloudEventsOptions{
+			URL:        target.URL,
+			Mode:       target.Mode,
+			Source:     clusterName,
+			MaxRetries: webhookMaxRetries,
+			Backoff:    time.Second,
+			Client:     &http.Client{Timeout: 10 * time.Second},
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to create the CloudEvents sink", "url", target.URL)
+			os.Exit(1)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	// The notification sinks hold back the events in quiet hours, the audit stream receives every event at once
+	var quietSinks []*notify.QuietSink
+	if len(auditPolicy.Escalation.QuietHours) != 0 {
+		for i, sink := range sinks {
+			quietSink := notify.NewQuietSink(ctrl.Log.WithName("notify"), sink, auditPolicy.Escalation.Quiet)
+			quietSinks = append(quietSinks, quietSink)
+			sinks[i] = quietSink
+		}
+	}
+
+	// exit closes the audit log before exiting, os.Exit skips deferred functions
+	closeAuditLog := func() {}
+	exit := func(code int) {
+		closeAuditLog()
+		os.Exit(code)
+	}
+
+	switch auditLogPath {
+	case "":
+	case "-":
+		sinks = append(sinks, notify.NewStreamSink("stdout", os.Stdout, clusterName))
+	default:
+		auditLog := &notify.RotatingFile{
+			Path:       auditLogPath,
+			MaxBytes:   int64(auditLogMaxSizeMB) * 1024 * 1024,
+			MaxBackups: auditLogMaxBackups,
+		}
+		closeAuditLog = func() {
+			if err := auditLog.Close(); err != nil {
+				setupLog.Error(err, "unable to close the audit log")
+			}
+		}
+		sinks = append(sinks, notify.NewStreamSink(auditLogPath, auditLog, clusterName))
+	}
+
+	var notifier *notify.Dispatcher
+	if len(sinks) != 0 {
+		notifier = notify.NewDispatcher(ctrl.Log.WithName("notify"), time.Minute, sinks...)
+	}
+
+	// A cache of the secrets would hold the keys certificate-only drops
+	if certificateOnly && !metadataOnlySecrets {
+		setupLog.Info("certificate-only implies metadata-only-secrets, only the metadata of the secrets is cached")
+		metadataOnlySecrets = true
+	}
+
+	// The secrets are read from the API server instead of a cache holding all of them with their keys
+	var clientOptions client.Options
+	if metadataOnlySecrets {
+		clientOptions.Cache = &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Client:                 clientOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -179,33 +444,79 @@ func main() {
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
-		os.Exit(1)
+		exit(1)
 	}
 
-	if err := (&controller.IngressTLSLogReconciler{
+	reconciler := &controller.IngressTLSLogReconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
 		Interval:             time.Duration(intervalSeconds) * time.Second,
 		IngressErrorMap:      store.NewIngressErrorMap(),
 		IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
-	}).SetupWithManager(mgr); err != nil {
+		IngressFindingMap:    store.NewIngressFindingMap(),
+		Escalation:           &auditPolicy.Escalation,
+		Probe: controller.ProbeConfig{
+			Port:                  probePort,
+			Address:               probeAddress,
+			UseLoadBalancerStatus: probeUseLoadBalancer,
+		},
+		Prober: prober.New(prober.Options{
+			Workers:       probeWorkers,
+			RatePerSecond: probeRate,
+			Burst:         probeBurst,
+			CacheTTL:      time.Duration(probeCacheTTLSeconds) * time.Second,
+		}),
+		MaxConcurrentReconciles:    maxConcurrentReconciles,
+		AllowCrossNamespaceSecrets: allowCrossNamespaceSecrets,
+		DefaultCertificate:         defaultCertificateKey,
+		Notifier:                   notifier,
+		LogMode:                    logMode,
+		Checkers:                   checkers,
+		DryRun:                     dryRun,
+		MetadataOnlySecrets:        metadataOnlySecrets,
+		APIReader:                  mgr.GetAPIReader(),
+		SecretMetadataReader:       mgr.GetCache(),
+		CertificateOnly:            certificateOnly,
+		AuditStatus:                auditStatus,
+		AnnotateIngresses:          annotateIngresses,
+		ExpiryWarning:              expiryWarning,
+	}
+	for _, quietSink := range quietSinks {
+		if err := mgr.Add(manager.RunnableFunc(quietSink.Run)); err != nil {
+			setupLog.Error(err, "unable to add the quiet hours of the notification sinks")
+			exit(1)
+		}
+	}
+
+	if err := reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IngressTLSLog")
-		os.Exit(1)
+		exit(1)
 	}
 	// +kubebuilder:scaffold:builder
 
+	if enableAPI {
+		// The API is served by the metrics server, so it is protected by the same authn/authz filter
+		if err := mgr.AddMetricsServerExtraHandler(api.Prefix, api.NewServer(mgr.GetClient(), reconciler, api.Options{
+			AllowCrossNamespaceSecrets: allowCrossNamespaceSecrets,
+		})); err != nil {
+			setupLog.Error(err, "unable to set up the API")
+			exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
-		os.Exit(1)
+		exit(1)
 	}
 	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+		exit(1)
 	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
-		os.Exit(1)
+		exit(1)
 	}
+	closeAuditLog()
 }
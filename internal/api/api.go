@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+	"github.com/MMMMMMorty/ingress-auditor/internal/report"
+)
+
+// Prefix is the path the API is served under
+const Prefix = "/api/v1/"
+
+// maxPolicySize limits the size of the candidate policy of the what-if evaluation
+const maxPolicySize = 1 << 20
+
+// State is the audit state held by the reconciler
+type State interface {
+	OpenFindings() []controller.OpenFinding
+	WhatIf(ctx context.Context, candidate *policy.Policy) (controller.PolicyDiff, error)
+	// Certificate returns the tls.crt of the secret, the boolean is false if it cannot be read
+	Certificate(ctx context.Context, key types.NamespacedName) ([]byte, bool)
+}
+
+// List is the response of the list endpoints
+type List[T any] struct {
+	Items []T `json:"items"`
+}
+
+// Ingress is the audit state of an ingress
+type Ingress struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Hosts     []string `json:"hosts"`
+	// TLS is true if the ingress defines TLS blocks
+	TLS bool `json:"tls"`
+	// Finding is the open finding of the ingress, nil if it passed all checks
+	Finding *controller.OpenFinding `json:"finding,omitempty"`
+	// ExemptUntil is the end of the exemption of the ingress
+	ExemptUntil string `json:"exemptUntil,omitempty"`
+}
+
+// Server serves the read-only JSON API from the cache of the manager and the state of the reconciler
+type Server struct {
+	reader client.Reader
+	state  State
+	opts   Options
+	mux    *http.ServeMux
+}
+
+// Options configures the API server like the corresponding fields of the reconciler
+type Options struct {
+	// AllowCrossNamespaceSecrets resolves secretName values like namespace/name
+	AllowCrossNamespaceSecrets bool
+}
+
+// NewServer creates the API server, its handler is registered under Prefix
+func NewServer(reader client.Reader, state State, opts Options) *Server {
+	s := &Server{reader: reader, state: state, opts: opts, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET "+Prefix+"ingresses", s.listIngresses)
+	s.mux.HandleFunc("GET "+Prefix+"findings", s.listFindings)
+	s.mux.HandleFunc("GET "+Prefix+"certificates", s.listCertificates)
+	s.mux.HandleFunc("GET "+Prefix+"summary", s.getSummary)
+	s.mux.HandleFunc("POST "+Prefix+"whatif", s.whatIf)
+
+	return s
+}
+
+// ServeHTTP serves the API, the namespace query parameter filters all endpoints
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mux.ServeHTTP(w, req)
+}
+
+// listIngresses serves the ingresses with their open finding
+func (s *Server) listIngresses(w http.ResponseWriter, req *http.Request) {
+	ingresses, err := s.ingresses(req)
+	if err != nil {
+		writeError(w, req, err)
+		return
+	}
+
+	findings := map[types.NamespacedName]controller.OpenFinding{}
+	for _, finding := range s.state.OpenFindings() {
+		findings[types.NamespacedName{Namespace: finding.Namespace, Name: finding.Ingress}] = finding
+	}
+
+	items := make([]Ingress, 0, len(ingresses))
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		item := Ingress{
+			Namespace:   ingress.Namespace,
+			Name:        ingress.Name,
+			Hosts:       report.HostsOf(ingress),
+			TLS:         len(ingress.Spec.TLS) != 0,
+			ExemptUntil: ingress.Annotations[controller.ExemptUntilAnnotation],
+		}
+		if finding, ok := findings[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}]; ok {
+			item.Finding = &finding
+		}
+		items = append(items, item)
+	}
+
+	writeJSON(w, List[Ingress]{Items: items})
+}
+
+// listFindings serves the open findings
+func (s *Server) listFindings(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get("namespace")
+
+	items := []controller.OpenFinding{}
+	for _, finding := range s.state.OpenFindings() {
+		if namespace == "" || finding.Namespace == namespace {
+			items = append(items, finding)
+		}
+	}
+
+	writeJSON(w, List[controller.OpenFinding]{Items: items})
+}
+
+// getSummary serves the counts of the open findings by reason, severity and namespace
+func (s *Server) getSummary(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get("namespace")
+
+	var findings []controller.OpenFinding
+	for _, finding := range s.state.OpenFindings() {
+		if namespace == "" || finding.Namespace == namespace {
+			findings = append(findings, finding)
+		}
+	}
+
+	writeJSON(w, controller.SummarizeFindings(findings))
+}
+
+// whatIf evaluates the candidate policy in the request body and serves the diff of its findings to the open findings
+func (s *Server) whatIf(w http.ResponseWriter, req *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxPolicySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	candidate, err := policy.Parse(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	diff, err := s.state.WhatIf(req.Context(), candidate)
+	if err != nil {
+		writeError(w, req, err)
+		return
+	}
+
+	if namespace := req.URL.Query().Get("namespace"); namespace != "" {
+		diff = filterDiff(diff, namespace)
+	}
+
+	writeJSON(w, diff)
+}
+
+// filterDiff returns the part of the diff in the namespace
+func filterDiff(diff controller.PolicyDiff, namespace string) controller.PolicyDiff {
+	filtered := controller.PolicyDiff{New: []controller.Finding{}, Removed: []controller.Finding{}, SeverityChanged: []controller.SeverityChange{}}
+	for _, finding := range diff.New {
+		if finding.Namespace == namespace {
+			filtered.New = append(filtered.New, finding)
+		}
+	}
+	for _, finding := range diff.Removed {
+		if finding.Namespace == namespace {
+			filtered.Removed = append(filtered.Removed, finding)
+		}
+	}
+	for _, change := range diff.SeverityChanged {
+		if change.Namespace == namespace {
+			filtered.SeverityChanged = append(filtered.SeverityChanged, change)
+		}
+	}
+
+	return filtered
+}
+
+// listCertificates serves the certificates in the TLS secrets of the ingresses
+func (s *Server) listCertificates(w http.ResponseWriter, req *http.Request) {
+	ingresses, err := s.ingresses(req)
+	if err != nil {
+		writeError(w, req, err)
+		return
+	}
+
+	// Only the certificates are read, through the reader of the checkers
+	secrets := func(key types.NamespacedName) (*v1.Secret, bool) {
+		crt, ok := s.state.Certificate(req.Context(), key)
+		if !ok {
+			return nil, false
+		}
+		return &v1.Secret{Data: map[string][]byte{v1.TLSCertKey: crt}}, true
+	}
+
+	writeJSON(w, List[report.Certificate]{Items: report.CertificateInventory(ingresses, secrets, s.opts.AllowCrossNamespaceSecrets)})
+}
+
+// ingresses lists the ingresses of the namespace query parameter, sorted by namespace and name
+func (s *Server) ingresses(req *http.Request) ([]networkingv1.Ingress, error) {
+	var listOpts []client.ListOption
+	if namespace := req.URL.Query().Get("namespace"); namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+
+	ingresses := &networkingv1.IngressList{}
+	if err := s.reader.List(req.Context(), ingresses, listOpts...); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ingresses.Items, func(i, j int) bool {
+		a, b := ingresses.Items[i], ingresses.Items[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return ingresses.Items, nil
+}
+
+// writeJSON writes the response
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logf.Log.WithName("api").Error(err, "failed to write response")
+	}
+}
+
+// writeError logs the error and responds with 500
+func writeError(w http.ResponseWriter, req *http.Request, err error) {
+	logf.Log.WithName("api").Error(err, "failed to serve request", "path", req.URL.Path)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
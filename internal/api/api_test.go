@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+	"github.com/MMMMMMorty/ingress-auditor/internal/report"
+)
+
+// staticState returns fixed open findings
+type staticState []controller.OpenFinding
+
+func (s staticState) OpenFindings() []controller.OpenFinding {
+	return s
+}
+
+// WhatIf removes the open findings when the candidate policy has no rule
+func (s staticState) WhatIf(_ context.Context, candidate *policy.Policy) (controller.PolicyDiff, error) {
+	diff := controller.PolicyDiff{New: []controller.Finding{}, Removed: []controller.Finding{}, SeverityChanged: []controller.SeverityChange{}}
+	if len(candidate.Rules) == 0 {
+		for _, finding := range s {
+			diff.Removed = append(diff.Removed, finding.Finding)
+		}
+	}
+	return diff, nil
+}
+
+// Certificate reads no secret
+func (s staticState) Certificate(context.Context, types.NamespacedName) ([]byte, bool) {
+	return nil, false
+}
+
+// certificateState returns fixed certificates of the secrets
+type certificateState struct {
+	staticState
+	certificates map[types.NamespacedName][]byte
+}
+
+func (s certificateState) Certificate(_ context.Context, key types.NamespacedName) ([]byte, bool) {
+	crt, ok := s.certificates[key]
+	return crt, ok
+}
+
+var _ = Describe("API", func() {
+	var server *Server
+	var c client.Client
+
+	newIngress := func(namespace, name, host string) *networkingv1.Ingress {
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		ingress.Spec.Rules = []networkingv1.IngressRule{{Host: host}}
+		return ingress
+	}
+
+	BeforeEach(func() {
+		c = fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			newIngress("ns-2", "b", "b.foo.com"),
+			newIngress("ns-1", "a", "a.foo.com"),
+		).Build()
+
+		firstSeen := time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC)
+		server = NewServer(c, staticState{{
+			Finding:   controller.Finding{Namespace: "ns-2", Ingress: "b", Reason: "HTTPRedirectMissing", Severity: controller.ErrLogLevel},
+			FirstSeen: firstSeen,
+			LastSeen:  firstSeen.Add(time.Hour),
+			Repeats:   1,
+		}}, Options{})
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	It("should list the ingresses with their open finding", func() {
+		recorder := get("/api/v1/ingresses")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		list := List[Ingress]{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &list)).To(Succeed())
+		Expect(list.Items).To(HaveLen(2))
+		Expect(list.Items[0].Name).To(Equal("a"))
+		Expect(list.Items[0].Finding).To(BeNil())
+		Expect(list.Items[1].Name).To(Equal("b"))
+		Expect(list.Items[1].Finding.Reason).To(Equal("HTTPRedirectMissing"))
+		Expect(list.Items[1].Finding.Repeats).To(Equal(1))
+	})
+
+	It("should filter by namespace", func() {
+		list := List[Ingress]{}
+		Expect(json.Unmarshal(get("/api/v1/ingresses?namespace=ns-1").Body.Bytes(), &list)).To(Succeed())
+		Expect(list.Items).To(HaveLen(1))
+
+		findings := List[controller.OpenFinding]{}
+		Expect(json.Unmarshal(get("/api/v1/findings?namespace=ns-1").Body.Bytes(), &findings)).To(Succeed())
+		Expect(findings.Items).To(BeEmpty())
+	})
+
+	It("should list the certificates", func() {
+		recorder := get("/api/v1/certificates")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		list := List[report.Certificate]{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &list)).To(Succeed())
+		Expect(list.Items).To(BeEmpty())
+	})
+
+	It("should read the certificates through the state", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "c.foo.com"},
+			DNSNames:     []string{"c.foo.com"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().AddDate(0, 3, 0),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+
+		// The secret is not in the client, the server must not read it
+		ingress := newIngress("ns-3", "c", "c.foo.com")
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{"c.foo.com"}, SecretName: "c-tls"}}
+		Expect(c.Create(context.Background(), ingress)).To(Succeed())
+		server = NewServer(c, certificateState{certificates: map[types.NamespacedName][]byte{
+			{Namespace: "ns-3", Name: "c-tls"}: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		}}, Options{})
+
+		list := List[report.Certificate]{}
+		Expect(json.Unmarshal(get("/api/v1/certificates").Body.Bytes(), &list)).To(Succeed())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Secret).To(Equal("ns-3/c-tls"))
+		Expect(list.Items[0].Ingresses).To(Equal([]string{"ns-3/c"}))
+	})
+
+	It("should summarise the open findings", func() {
+		recorder := get("/api/v1/summary")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		summary := controller.Summary{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &summary)).To(Succeed())
+		Expect(summary.Total).To(Equal(1))
+		Expect(summary.ByReason).To(Equal(map[string]int{"HTTPRedirectMissing": 1}))
+		Expect(summary.BySeverity).To(Equal(map[string]int{controller.ErrLogLevel: 1}))
+		Expect(summary.ByNamespace).To(Equal(map[string]int{"ns-2": 1}))
+
+		Expect(json.Unmarshal(get("/api/v1/summary?namespace=ns-1").Body.Bytes(), &summary)).To(Succeed())
+		Expect(summary.Total).To(BeZero())
+	})
+
+	It("should diff the findings of a candidate policy", func() {
+		post := func(path, body string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+			return recorder
+		}
+
+		recorder := post("/api/v1/whatif", "escalation:\n  escalateAfterIntervals: 2\n")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		diff := controller.PolicyDiff{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &diff)).To(Succeed())
+		Expect(diff.New).To(BeEmpty())
+		Expect(diff.Removed).To(HaveLen(1))
+		Expect(diff.Removed[0].Ingress).To(Equal("b"))
+
+		Expect(json.Unmarshal(post("/api/v1/whatif?namespace=ns-1", "").Body.Bytes(), &diff)).To(Succeed())
+		Expect(diff.Removed).To(BeEmpty())
+
+		Expect(post("/api/v1/whatif", "unknown: true").Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should only serve reads", func() {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/findings", nil))
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+
+		Expect(get("/api/v1/unknown").Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,186 @@
+package certmanager
+
+import (
+	"context"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Annotations of the ingress that make cert-manager issue its certificates
+const (
+	ClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+	IssuerAnnotation        = "cert-manager.io/issuer"
+)
+
+// certificateNameAnnotation and certificateRevisionAnnotation link a CertificateRequest to its Certificate
+const (
+	certificateNameAnnotation     = "cert-manager.io/certificate-name"
+	certificateRevisionAnnotation = "cert-manager.io/certificate-revision"
+)
+
+// CertificateNameIndex indexes the CertificateRequests by the name of their Certificate, see IndexCertificateRequests
+const CertificateNameIndex = "metadata.annotations.certificate-name"
+
+// cert-manager types are read as unstructured, so cert-manager is not a dependency
+var (
+	CertificateGVK            = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+	CertificateRequestGVK     = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "CertificateRequest"}
+	CertificateRequestListGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "CertificateRequestList"}
+)
+
+// CertificateStatus summarises a cert-manager Certificate and its latest CertificateRequest
+type CertificateStatus struct {
+	// Ready is true when the Ready condition of the Certificate is True
+	Ready bool
+	// Message is the message of the Ready condition
+	Message string
+	// Issuing is true while cert-manager is issuing or renewing the certificate
+	Issuing bool
+	// FailedIssuanceAttempts counts the failed issuances since the last success
+	FailedIssuanceAttempts int64
+	// RequestFailed is true when the latest CertificateRequest failed or was denied
+	RequestFailed bool
+	// RequestMessage is the message of the failed CertificateRequest
+	RequestMessage string
+}
+
+// Failing reports whether cert-manager failed to issue or renew the certificate
+func (s *CertificateStatus) Failing() bool {
+	return s.FailedIssuanceAttempts > 0 || s.RequestFailed
+}
+
+// Manages reports whether the certificates of the ingress are issued by cert-manager
+func Manages(annotations map[string]string) bool {
+	return annotations[ClusterIssuerAnnotation] != "" || annotations[IssuerAnnotation] != ""
+}
+
+// Installed reports whether the API server serves the CertificateRequests of cert-manager
+func Installed(mapper meta.RESTMapper) (bool, error) {
+	if _, err := mapper.RESTMapping(CertificateRequestGVK.GroupKind(), CertificateRequestGVK.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// IndexCertificateRequests registers CertificateNameIndex in the indexer, so the requests of a Certificate
+// are listed from the cache instead of listing all CertificateRequests of the namespace from the API server
+func IndexCertificateRequests(ctx context.Context, indexer client.FieldIndexer) error {
+	request := &unstructured.Unstructured{}
+	request.SetGroupVersionKind(CertificateRequestGVK)
+
+	return indexer.IndexField(ctx, request, CertificateNameIndex, certificateName)
+}
+
+// certificateName returns the name of the Certificate of a CertificateRequest
+func certificateName(obj client.Object) []string {
+	name := obj.GetAnnotations()[certificateNameAnnotation]
+	if name == "" {
+		return nil
+	}
+
+	return []string{name}
+}
+
+// GetCertificateStatus reads the Certificate and its latest CertificateRequest.
+// The reader must have CertificateNameIndex registered, see IndexCertificateRequests.
+// It returns nil without error if cert-manager is not installed or the Certificate does not exist.
+func GetCertificateStatus(ctx context.Context, c client.Reader, key types.NamespacedName) (*CertificateStatus, error) {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertificateGVK)
+
+	if err := c.Get(ctx, key, certificate); err != nil {
+		if meta.IsNoMatchError(err) || errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	status := &CertificateStatus{}
+
+	ready := findCondition(certificate, "Ready")
+	if ready != nil {
+		status.Ready = ready["status"] == "True"
+		status.Message, _ = ready["message"].(string)
+	}
+
+	issuing := findCondition(certificate, "Issuing")
+	status.Issuing = issuing != nil && issuing["status"] == "True"
+
+	status.FailedIssuanceAttempts, _, _ = unstructured.NestedInt64(certificate.Object, "status", "failedIssuanceAttempts")
+
+	request, err := latestCertificateRequest(ctx, c, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if request != nil {
+		if denied := findCondition(request, "Denied"); denied != nil && denied["status"] == "True" {
+			status.RequestFailed = true
+			status.RequestMessage, _ = denied["message"].(string)
+		}
+		if invalid := findCondition(request, "InvalidRequest"); invalid != nil && invalid["status"] == "True" {
+			status.RequestFailed = true
+			status.RequestMessage, _ = invalid["message"].(string)
+		}
+		if ready := findCondition(request, "Ready"); ready != nil && ready["reason"] == "Failed" {
+			status.RequestFailed = true
+			status.RequestMessage, _ = ready["message"].(string)
+		}
+	}
+
+	return status, nil
+}
+
+// latestCertificateRequest returns the CertificateRequest of the Certificate with the highest revision
+func latestCertificateRequest(ctx context.Context, c client.Reader, key types.NamespacedName) (*unstructured.Unstructured, error) {
+	requests := &unstructured.UnstructuredList{}
+	requests.SetGroupVersionKind(CertificateRequestListGVK)
+
+	if err := c.List(ctx, requests, client.InNamespace(key.Namespace),
+		client.MatchingFields{CertificateNameIndex: key.Name}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var latest *unstructured.Unstructured
+	latestRevision := -1
+	for i := range requests.Items {
+		request := &requests.Items[i]
+		revision, err := strconv.Atoi(request.GetAnnotations()[certificateRevisionAnnotation])
+		if err != nil {
+			revision = 0
+		}
+
+		if revision > latestRevision {
+			latest = request
+			latestRevision = revision
+		}
+	}
+
+	return latest, nil
+}
+
+// findCondition returns the condition of the type in status.conditions
+func findCondition(obj *unstructured.Unstructured, conditionType string) map[string]interface{} {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == conditionType {
+			return condition
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package certmanager
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCertManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "CertManager Suite")
+}
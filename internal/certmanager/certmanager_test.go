@@ -0,0 +1,122 @@
+package certmanager
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("GetCertificateStatus", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "secret-tls", Namespace: "default"}
+
+	newClient := func(objs ...client.Object) client.Client {
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{CertificateGVK.GroupVersion()})
+		mapper.Add(CertificateGVK, meta.RESTScopeNamespace)
+		mapper.Add(CertificateGVK.GroupVersion().WithKind("CertificateRequest"), meta.RESTScopeNamespace)
+
+		request := &unstructured.Unstructured{}
+		request.SetGroupVersionKind(CertificateRequestGVK)
+
+		return fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithRESTMapper(mapper).
+			WithIndex(request, CertificateNameIndex, certificateName).WithObjects(objs...).Build()
+	}
+
+	newObject := func(kind, name string, annotations map[string]string, status map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+		obj.SetGroupVersionKind(CertificateGVK.GroupVersion().WithKind(kind))
+		obj.SetName(name)
+		obj.SetNamespace("default")
+		obj.SetAnnotations(annotations)
+		return obj
+	}
+
+	It("should return nil if cert-manager is not installed", func() {
+		c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).
+			WithRESTMapper(meta.NewDefaultRESTMapper(nil)).Build()
+
+		status, err := GetCertificateStatus(ctx, c, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+	})
+
+	It("should return nil if the Certificate does not exist", func() {
+		status, err := GetCertificateStatus(ctx, newClient(), key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+	})
+
+	It("should report a ready Certificate", func() {
+		certificate := newObject("Certificate", key.Name, nil, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True", "message": "Certificate is up to date"},
+			},
+		})
+
+		status, err := GetCertificateStatus(ctx, newClient(certificate), key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Ready).To(BeTrue())
+		Expect(status.Failing()).To(BeFalse())
+	})
+
+	It("should report the failure of the latest CertificateRequest", func() {
+		certificate := newObject("Certificate", key.Name, nil, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "False", "message": "Issuing certificate"},
+				map[string]interface{}{"type": "Issuing", "status": "True"},
+			},
+		})
+		oldRequest := newObject("CertificateRequest", "secret-tls-1", map[string]string{
+			certificateNameAnnotation:     key.Name,
+			certificateRevisionAnnotation: "1",
+		}, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True", "reason": "Issued"},
+			},
+		})
+		newRequest := newObject("CertificateRequest", "secret-tls-2", map[string]string{
+			certificateNameAnnotation:     key.Name,
+			certificateRevisionAnnotation: "2",
+		}, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "False", "reason": "Failed", "message": "rate limited"},
+			},
+		})
+
+		otherRequest := newObject("CertificateRequest", "other-tls-3", map[string]string{
+			certificateNameAnnotation:     "other-tls",
+			certificateRevisionAnnotation: "3",
+		}, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Denied", "status": "True", "message": "denied"},
+			},
+		})
+
+		status, err := GetCertificateStatus(ctx, newClient(certificate, oldRequest, newRequest, otherRequest), key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Ready).To(BeFalse())
+		Expect(status.Issuing).To(BeTrue())
+		Expect(status.Failing()).To(BeTrue())
+		Expect(status.RequestMessage).To(Equal("rate limited"))
+	})
+
+	It("should only report cert-manager as installed if it serves CertificateRequests", func() {
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{CertificateGVK.GroupVersion()})
+		installed, err := Installed(mapper)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(installed).To(BeFalse())
+
+		mapper.Add(CertificateRequestGVK, meta.RESTScopeNamespace)
+		installed, err = Installed(mapper)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(installed).To(BeTrue())
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	networkingv1ac "k8s.io/client-go/applyconfigurations/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Annotations written back to the audited ingresses if AnnotateIngresses is set
+const (
+	// StatusAnnotation is Passed or Failed
+	StatusAnnotation = "ingress-audit.morty.dev/status"
+	// LastCheckedAnnotation is the RFC 3339 time of the last audit
+	LastCheckedAnnotation = "ingress-audit.morty.dev/last-checked"
+	// CertExpiryAnnotation is the RFC 3339 expiry of the first expiring certificate, it is removed if none is read
+	CertExpiryAnnotation = "ingress-audit.morty.dev/cert-expiry"
+	// FindingsAnnotation is the comma-separated reason codes of the findings, it is removed if there are none
+	FindingsAnnotation = "ingress-audit.morty.dev/findings"
+)
+
+// AnnotationFieldManager owns the annotations written back with server-side apply, so no other field is changed
+const AnnotationFieldManager = "ingress-auditor-annotations"
+
+// The values of StatusAnnotation
+const (
+	AuditPassed = "Passed"
+	AuditFailed = "Failed"
+)
+
+// auditAnnotationKeys are the annotations written back, they are ignored by the watch of the ingresses
+var auditAnnotationKeys = []string{StatusAnnotation, LastCheckedAnnotation, CertExpiryAnnotation, FindingsAnnotation}
+
+// auditAnnotations returns the annotations summarising the audit of the ingress
+func auditAnnotations(ctx context.Context, ingress *networkingv1.Ingress, findings []Finding, deps *CheckDeps, now time.Time) map[string]string {
+	annotations := map[string]string{
+		StatusAnnotation:      AuditPassed,
+		LastCheckedAnnotation: now.UTC().Format(time.RFC3339),
+	}
+
+	var reasons []string
+	for _, finding := range findings {
+		if !slices.Contains(reasons, finding.Reason) {
+			reasons = append(reasons, finding.Reason)
+		}
+	}
+	if len(reasons) != 0 {
+		annotations[StatusAnnotation] = AuditFailed
+		annotations[FindingsAnnotation] = strings.Join(reasons, ",")
+	}
+
+	var expiry time.Time
+	for _, certificate := range hostCertificates(ctx, ingress, deps) {
+		if expiry.IsZero() || certificate.NotAfter.Time.Before(expiry) {
+			expiry = certificate.NotAfter.Time
+		}
+	}
+	if !expiry.IsZero() {
+		annotations[CertExpiryAnnotation] = expiry.UTC().Format(time.RFC3339)
+	}
+
+	return annotations
+}
+
+// annotateIngress writes the audit annotations back to the ingress with server-side apply.
+// The UID makes the apply fail instead of creating the ingress again if it was deleted.
+func (r *IngressTLSLogReconciler) annotateIngress(ctx context.Context, ingress *networkingv1.Ingress, findings []Finding, deps *CheckDeps) error {
+	apply := networkingv1ac.Ingress(ingress.Name, ingress.Namespace).
+		WithUID(ingress.UID).
+		WithAnnotations(auditAnnotations(ctx, ingress, findings, deps, time.Now()))
+
+	return r.Apply(ctx, apply, client.FieldOwner(AnnotationFieldManager), client.ForceOwnership)
+}
+
+// ignoreAuditAnnotationUpdates drops the updates of ingresses only changing the audit annotations,
+// so writing them back does not reconcile the ingress again
+func ignoreAuditAnnotationUpdates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldIngress, ok := e.ObjectOld.(*networkingv1.Ingress)
+			if !ok {
+				return true
+			}
+			newIngress, ok := e.ObjectNew.(*networkingv1.Ingress)
+			if !ok {
+				return true
+			}
+
+			return !equality.Semantic.DeepEqual(withoutAuditAnnotations(oldIngress), withoutAuditAnnotations(newIngress))
+		},
+	}
+}
+
+// withoutAuditAnnotations returns a copy of the ingress without the audit annotations and the metadata changed by writing them
+func withoutAuditAnnotations(ingress *networkingv1.Ingress) *networkingv1.Ingress {
+	ingress = ingress.DeepCopy()
+	for _, key := range auditAnnotationKeys {
+		delete(ingress.Annotations, key)
+	}
+	if len(ingress.Annotations) == 0 {
+		ingress.Annotations = nil
+	}
+	ingress.ResourceVersion = ""
+	ingress.ManagedFields = nil
+
+	return ingress
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("Ingress annotations", func() {
+	ctx := context.Background()
+
+	It("should write the audit summary back without changing other annotations", func() {
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "web",
+			UID:         "uid",
+			Annotations: map[string]string{"team": "shop"},
+		}}
+		r := &IngressTLSLogReconciler{Client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(ingress).Build()}
+		deps := r.checkDeps(GinkgoLogr)
+
+		Expect(r.annotateIngress(ctx, ingress, []Finding{NewFinding(ingress, "", ErrHTTPRedirectMissing, nil)}, deps)).To(Succeed())
+
+		annotated := &networkingv1.Ingress{}
+		Expect(r.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "web"}, annotated)).To(Succeed())
+		Expect(annotated.Annotations).To(HaveKeyWithValue("team", "shop"))
+		Expect(annotated.Annotations).To(HaveKeyWithValue(StatusAnnotation, AuditFailed))
+		Expect(annotated.Annotations).To(HaveKeyWithValue(FindingsAnnotation, ReasonFor(ErrHTTPRedirectMissing).Code))
+		Expect(annotated.Annotations).To(HaveKey(LastCheckedAnnotation))
+		Expect(annotated.Annotations).NotTo(HaveKey(CertExpiryAnnotation))
+
+		Expect(r.annotateIngress(ctx, annotated, nil, deps)).To(Succeed())
+
+		Expect(r.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "web"}, annotated)).To(Succeed())
+		Expect(annotated.Annotations).To(HaveKeyWithValue("team", "shop"))
+		Expect(annotated.Annotations).To(HaveKeyWithValue(StatusAnnotation, AuditPassed))
+		Expect(annotated.Annotations).NotTo(HaveKey(FindingsAnnotation))
+	})
+
+	It("should ignore the updates only changing the audit annotations", func() {
+		oldIngress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", ResourceVersion: "1"}}
+		newIngress := oldIngress.DeepCopy()
+		newIngress.ResourceVersion = "2"
+		newIngress.Annotations = map[string]string{StatusAnnotation: AuditPassed, LastCheckedAnnotation: "2025-12-12T00:00:00Z"}
+
+		p := ignoreAuditAnnotationUpdates()
+		Expect(p.Update(event.UpdateEvent{ObjectOld: oldIngress, ObjectNew: newIngress})).To(BeFalse())
+
+		newIngress.Annotations[ExemptUntilAnnotation] = "2025-12-13T00:00:00Z"
+		Expect(p.Update(event.UpdateEvent{ObjectOld: oldIngress, ObjectNew: newIngress})).To(BeTrue())
+	})
+})
@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+// defaultExpiryWarning is the ExpiryWarning of reconcilers without one, like in the static checks of the CLI
+const defaultExpiryWarning = 30 * 24 * time.Hour
+
+// The reasons of the conditions of IngressAuditStatus, failed checks use the code of their finding
+const (
+	// ChecksPassedReason means all checks of the condition passed
+	ChecksPassedReason = "ChecksPassed"
+	// NotCheckedReason means the checks of the condition did not run, e.g. because an earlier check failed
+	NotCheckedReason = "NotChecked"
+	// TLSNotConfiguredReason means the ingress defines no TLS block
+	TLSNotConfiguredReason = "TLSNotConfigured"
+	// NoCertificateReason means no certificate of the ingress could be read
+	NoCertificateReason = "NoCertificate"
+	// CertificateNotExpiringReason means no certificate of the ingress expires within the expiry warning
+	CertificateNotExpiringReason = "CertificateNotExpiring"
+)
+
+// conditionCheckers are the built-in checkers deciding the conditions of IngressAuditStatus, ExpiryOK
+// is decided by the certificates. The findings of other checkers, e.g. custom rules, change no condition.
+var conditionCheckers = []struct {
+	conditionType string
+	checkers      []string
+}{
+	{ingressauditv1alpha1.TLSConfiguredCondition, []string{SecretNameChecker, CertManagerChecker, SecretChecker, HostsChecker, HostCoverageChecker}},
+	{ingressauditv1alpha1.CertificateValidCondition, []string{TLSHandshakeChecker, CertificateSANChecker}},
+	{ingressauditv1alpha1.RedirectEnforcedCondition, []string{HTTPRedirectChecker}},
+}
+
+// syncAuditStatus creates or updates the IngressAuditStatus of the ingress with the findings of the checkers
+func (r *IngressTLSLogReconciler) syncAuditStatus(ctx context.Context, ingress *networkingv1.Ingress, findings []Finding, deps *CheckDeps) error {
+	status := &ingressauditv1alpha1.IngressAuditStatus{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}, status)
+	if apierrors.IsNotFound(err) {
+		status = &ingressauditv1alpha1.IngressAuditStatus{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ingress.Namespace, Name: ingress.Name},
+			Spec:       ingressauditv1alpha1.IngressAuditStatusSpec{IngressName: ingress.Name},
+		}
+		// The status is garbage collected with the ingress
+		if err := controllerutil.SetControllerReference(ingress, status, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, status); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	// The status is patched, so a stale cached object does not fail the reconcile with a conflict
+	base := status.DeepCopy()
+	now := metav1.Now()
+	certificates := hostCertificates(ctx, ingress, deps)
+	for _, condition := range r.auditConditions(ingress, findings, certificates, now.Time) {
+		condition.ObservedGeneration = ingress.Generation
+		meta.SetStatusCondition(&status.Status.Conditions, condition)
+	}
+	status.Status.Certificates = certificates
+	status.Status.LastCheckedTime = &now
+	status.Status.ObservedGeneration = ingress.Generation
+
+	return r.Status().Patch(ctx, status, client.MergeFrom(base))
+}
+
+// CurrentFinding returns the failed condition of the IngressAuditStatus holding the finding of the last audit,
+// or nil if the ingress passed it. An ingress without TLS only has a finding if it does not redirect HTTP traffic,
+// and ExpiryOK is no finding.
+func CurrentFinding(status *ingressauditv1alpha1.IngressAuditStatus) *metav1.Condition {
+	for _, c := range conditionCheckers {
+		condition := meta.FindStatusCondition(status.Status.Conditions, c.conditionType)
+		if condition != nil && condition.Status == metav1.ConditionFalse && condition.Reason != TLSNotConfiguredReason {
+			return condition
+		}
+	}
+
+	return nil
+}
+
+// auditConditions returns the conditions of the ingress.
+// The checkers run until the first finding, so the conditions of the later checkers are Unknown.
+func (r *IngressTLSLogReconciler) auditConditions(
+	ingress *networkingv1.Ingress,
+	findings []Finding,
+	certificates []ingressauditv1alpha1.HostCertificate,
+	now time.Time,
+) []metav1.Condition {
+	// The checkers which ran, the last one reported the findings
+	ran := map[string]bool{}
+	failed := ""
+	for _, checker := range r.checkers().Enabled() {
+		ran[checker.Name()] = true
+		if len(findings) != 0 && slices.Contains(checker.Reasons(), findings[0].Reason) {
+			failed = checker.Name()
+			break
+		}
+	}
+
+	conditions := make([]metav1.Condition, 0, len(conditionCheckers)+1)
+	for _, c := range conditionCheckers {
+		condition := metav1.Condition{Type: c.conditionType}
+		enabled := slices.ContainsFunc(c.checkers, func(name string) bool {
+			return r.checkers().IsEnabled(name)
+		})
+		passed := enabled && !slices.ContainsFunc(c.checkers, func(name string) bool {
+			return r.checkers().IsEnabled(name) && !ran[name]
+		})
+
+		switch {
+		case slices.Contains(c.checkers, failed):
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = findings[0].Reason
+			condition.Message = findings[0].Message
+		case c.conditionType == ingressauditv1alpha1.TLSConfiguredCondition && len(ingress.Spec.TLS) == 0:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = TLSNotConfiguredReason
+			condition.Message = "the ingress defines no TLS block"
+		case c.conditionType == ingressauditv1alpha1.CertificateValidCondition && len(ingress.Spec.TLS) == 0:
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = NoCertificateReason
+			condition.Message = "the ingress defines no TLS block"
+		case !enabled:
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = NotCheckedReason
+			condition.Message = "the checks of the condition are disabled"
+		case !passed:
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = NotCheckedReason
+			condition.Message = "an earlier check failed"
+		default:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = ChecksPassedReason
+			condition.Message = "all checks passed"
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return append(conditions, r.expiryCondition(certificates, now))
+}
+
+// expiryCondition returns the ExpiryOK condition of the earliest expiring certificate
+func (r *IngressTLSLogReconciler) expiryCondition(certificates []ingressauditv1alpha1.HostCertificate, now time.Time) metav1.Condition {
+	condition := metav1.Condition{Type: ingressauditv1alpha1.ExpiryOKCondition}
+
+	var earliest *ingressauditv1alpha1.HostCertificate
+	for i := range certificates {
+		if earliest == nil || certificates[i].NotAfter.Before(earliest.NotAfter) {
+			earliest = &certificates[i]
+		}
+	}
+
+	expiryWarning := r.ExpiryWarning
+	if expiryWarning <= 0 {
+		expiryWarning = defaultExpiryWarning
+	}
+
+	switch {
+	case earliest == nil:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = NoCertificateReason
+		condition.Message = "no certificate of the ingress could be read"
+	case now.After(earliest.NotAfter.Time):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonFor(ErrCertificateExpired).Code
+		condition.Message = fmt.Sprintf("the certificate of %s expired at %s", earliest.Host, earliest.NotAfter.Format(time.RFC3339))
+	case now.Add(expiryWarning).After(earliest.NotAfter.Time):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonFor(ErrCertificateExpiringSoon).Code
+		condition.Message = fmt.Sprintf("the certificate of %s expires at %s", earliest.Host, earliest.NotAfter.Format(time.RFC3339))
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = CertificateNotExpiringReason
+		condition.Message = fmt.Sprintf("the first certificate expires at %s", earliest.NotAfter.Format(time.RFC3339))
+	}
+
+	return condition
+}
+
+// hostCertificates returns the parsed certificates of the TLS hosts of the ingress, sorted by host
+func hostCertificates(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []ingressauditv1alpha1.HostCertificate {
+	certificates := []ingressauditv1alpha1.HostCertificate{}
+	seen := map[string]bool{}
+	for _, tlsInstance := range ingress.Spec.TLS {
+		crt, _, ok := deps.TLSSecret(ctx, ingress, tlsInstance.SecretName)
+		if !ok {
+			continue
+		}
+		cert, err := utils.ParseCertificate(crt)
+		if err != nil {
+			continue
+		}
+
+		for _, host := range tlsInstance.Hosts {
+			// The first TLS block of a host is served
+			if seen[host] {
+				continue
+			}
+			seen[host] = true
+
+			certificates = append(certificates, ingressauditv1alpha1.HostCertificate{
+				Host:         host,
+				SecretName:   tlsInstance.SecretName,
+				Subject:      cert.Subject.String(),
+				Issuer:       cert.Issuer.String(),
+				DNSNames:     cert.DNSNames,
+				SerialNumber: cert.SerialNumber.String(),
+				NotBefore:    &metav1.Time{Time: cert.NotBefore},
+				NotAfter:     &metav1.Time{Time: cert.NotAfter},
+				Fingerprint:  utils.Fingerprint(crt),
+			})
+		}
+	}
+
+	sort.Slice(certificates, func(i, j int) bool {
+		return certificates[i].Host < certificates[j].Host
+	})
+
+	return certificates
+}
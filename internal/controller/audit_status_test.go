@@ -0,0 +1,185 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+)
+
+// newTestCertificate creates a self-signed PEM certificate of the host
+func newTestCertificate(notAfter time.Time, host string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+var _ = Describe("Audit status", func() {
+	ctx := context.Background()
+
+	It("should keep the conditions and certificates of the ingress in sync", func() {
+		testScheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+		Expect(ingressauditv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		notAfter := time.Now().Add(10 * 24 * time.Hour).Truncate(time.Second)
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tls"},
+			Type:       v1.SecretTypeTLS,
+			Data:       map[string][]byte{v1.TLSCertKey: newTestCertificate(notAfter, "foo.com"), v1.TLSPrivateKeyKey: []byte("key")},
+		}
+		ingress := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", UID: "uid", Generation: 2},
+			Spec: networkingv1.IngressSpec{
+				TLS:   []networkingv1.IngressTLS{{SecretName: "tls", Hosts: []string{"foo.com"}}},
+				Rules: []networkingv1.IngressRule{{Host: "foo.com"}},
+			},
+		}
+		r := &IngressTLSLogReconciler{
+			Client:      fake.NewClientBuilder().WithScheme(testScheme).WithObjects(ingress, secret).WithStatusSubresource(&ingressauditv1alpha1.IngressAuditStatus{}).Build(),
+			Scheme:      testScheme,
+			AuditStatus: true,
+		}
+
+		Expect(r.syncAuditStatus(ctx, ingress, nil, r.checkDeps(GinkgoLogr))).To(Succeed())
+
+		status := &ingressauditv1alpha1.IngressAuditStatus{}
+		Expect(r.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "web"}, status)).To(Succeed())
+		Expect(status.Spec.IngressName).To(Equal("web"))
+		Expect(status.OwnerReferences).To(ConsistOf(HaveField("Name", "web")))
+		Expect(status.Status.LastCheckedTime).NotTo(BeNil())
+		Expect(status.Status.ObservedGeneration).To(Equal(int64(2)))
+		Expect(status.Status.Certificates).To(HaveLen(1))
+		Expect(status.Status.Certificates[0].Host).To(Equal("foo.com"))
+		Expect(status.Status.Certificates[0].NotAfter.Time).To(BeTemporally("==", notAfter))
+
+		conditions := status.Status.Conditions
+		Expect(meta.IsStatusConditionTrue(conditions, ingressauditv1alpha1.TLSConfiguredCondition)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(conditions, ingressauditv1alpha1.CertificateValidCondition)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(conditions, ingressauditv1alpha1.RedirectEnforcedCondition)).To(BeTrue())
+		expiry := meta.FindStatusCondition(conditions, ingressauditv1alpha1.ExpiryOKCondition)
+		Expect(expiry.Status).To(Equal(metav1.ConditionFalse))
+		Expect(expiry.Reason).To(Equal(ReasonFor(ErrCertificateExpiringSoon).Code))
+
+		findings := []Finding{NewFinding(ingress, "foo.com", ErrHostnameMismatch, nil)}
+		Expect(r.syncAuditStatus(ctx, ingress, findings, r.checkDeps(GinkgoLogr))).To(Succeed())
+		Expect(r.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "web"}, status)).To(Succeed())
+
+		// The host-coverage checker of TLSConfigured runs after the failed tls-handshake checker
+		conditions = status.Status.Conditions
+		Expect(meta.FindStatusCondition(conditions, ingressauditv1alpha1.TLSConfiguredCondition).Reason).To(Equal(NotCheckedReason))
+		certificateValid := meta.FindStatusCondition(conditions, ingressauditv1alpha1.CertificateValidCondition)
+		Expect(certificateValid.Status).To(Equal(metav1.ConditionFalse))
+		Expect(certificateValid.Reason).To(Equal(ReasonFor(ErrHostnameMismatch).Code))
+		redirect := meta.FindStatusCondition(conditions, ingressauditv1alpha1.RedirectEnforcedCondition)
+		Expect(redirect.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(redirect.Reason).To(Equal(NotCheckedReason))
+	})
+
+	It("should patch the status of a stale cached object without a conflict", func() {
+		testScheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+		Expect(ingressauditv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", UID: "uid", Generation: 3}}
+		existing := &ingressauditv1alpha1.IngressAuditStatus{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+			Spec:       ingressauditv1alpha1.IngressAuditStatusSpec{IngressName: "web"},
+		}
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(ingress, existing).
+			WithStatusSubresource(&ingressauditv1alpha1.IngressAuditStatus{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				// The cache has not seen the last write of the status yet
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if err := c.Get(ctx, key, obj, opts...); err != nil {
+						return err
+					}
+					if _, ok := obj.(*ingressauditv1alpha1.IngressAuditStatus); ok {
+						obj.SetResourceVersion("1")
+					}
+					return nil
+				},
+			}).
+			Build()
+		r := &IngressTLSLogReconciler{Client: c, Scheme: testScheme, AuditStatus: true}
+
+		Expect(r.syncAuditStatus(ctx, ingress, nil, r.checkDeps(GinkgoLogr))).To(Succeed())
+		Expect(r.syncAuditStatus(ctx, ingress, nil, r.checkDeps(GinkgoLogr))).To(Succeed())
+
+		status := &ingressauditv1alpha1.IngressAuditStatus{}
+		Expect(r.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "web"}, status)).To(Succeed())
+		Expect(status.Status.ObservedGeneration).To(Equal(int64(3)))
+	})
+
+	It("should report ingresses without TLS", func() {
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "plain"}}
+		r := &IngressTLSLogReconciler{}
+
+		conditions := r.auditConditions(ingress, nil, nil, time.Now())
+		Expect(meta.FindStatusCondition(conditions, ingressauditv1alpha1.TLSConfiguredCondition).Reason).To(Equal(TLSNotConfiguredReason))
+		Expect(meta.FindStatusCondition(conditions, ingressauditv1alpha1.CertificateValidCondition).Reason).To(Equal(NoCertificateReason))
+		Expect(meta.FindStatusCondition(conditions, ingressauditv1alpha1.ExpiryOKCondition).Status).To(Equal(metav1.ConditionUnknown))
+	})
+
+	It("should only report an ingress without TLS as finding if it does not redirect", func() {
+		status := &ingressauditv1alpha1.IngressAuditStatus{Status: ingressauditv1alpha1.IngressAuditStatusStatus{
+			Conditions: []metav1.Condition{
+				{Type: ingressauditv1alpha1.TLSConfiguredCondition, Status: metav1.ConditionFalse, Reason: TLSNotConfiguredReason},
+				{Type: ingressauditv1alpha1.RedirectEnforcedCondition, Status: metav1.ConditionTrue, Reason: ChecksPassedReason},
+				{Type: ingressauditv1alpha1.ExpiryOKCondition, Status: metav1.ConditionFalse, Reason: NoCertificateReason},
+			},
+		}}
+		Expect(CurrentFinding(status)).To(BeNil())
+
+		status.Status.Conditions[1].Status = metav1.ConditionFalse
+		status.Status.Conditions[1].Reason = ReasonFor(ErrHTTPRedirectMissing).Code
+		Expect(CurrentFinding(status).Reason).To(Equal("HTTPRedirectMissing"))
+	})
+})
@@ -0,0 +1,261 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/certmanager"
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+// Names of the built-in checkers
+const (
+	SecretNameChecker     = "secret-name"
+	CertManagerChecker    = "cert-manager"
+	SecretChecker         = "secret"
+	HostsChecker          = "hosts"
+	TLSHandshakeChecker   = "tls-handshake"
+	CertificateSANChecker = "certificate-san"
+	HostCoverageChecker   = "host-coverage"
+	HTTPRedirectChecker   = "http-redirect"
+)
+
+// BuiltinCheckers returns the built-in checkers in the order the reconciler runs them
+func BuiltinCheckers() []Checker {
+	return []Checker{
+		NewChecker(SecretNameChecker, []error{ErrSecretNameMissing, ErrFetchSecret}, checkSecretName),
+		NewChecker(CertManagerChecker, []error{
+			ErrCertificateIssuancePending, ErrCertificateIssuanceFailed, ErrCertificateRenewalFailing, ErrCertificateNotReady,
+		}, checkCertManager),
+		NewChecker(SecretChecker, []error{ErrFetchSecret, ErrCrtOrKeyMissing}, checkSecret),
+		NewChecker(HostsChecker, []error{ErrHostsMissing}, checkHosts),
+		// The static check of the certificate runs before the handshake probing the network
+		NewChecker(CertificateSANChecker, []error{ErrCertificateSANMismatch}, checkCertificateSAN),
+		NewChecker(TLSHandshakeChecker, []error{
+			ErrTLSVerification, ErrDNSResolution, ErrConnectionRefused, ErrConnectionTimeout, ErrNetworkUnreachable,
+			ErrTLSHandshake, ErrHostnameMismatch, ErrCertificateExpired, ErrCertificateNotYetValid, ErrUnknownAuthority,
+		}, checkTLSHandshake),
+		NewChecker(HostCoverageChecker, []error{ErrRuleHostNotCovered, ErrTLSHostWithoutRule, ErrDefaultBackendOnly}, checkHostCoverageFindings),
+		NewChecker(HTTPRedirectChecker, []error{ErrHTTPRedirectMissing}, checkHTTPRedirect),
+	}
+}
+
+// NewBuiltinCheckerRegistry creates a registry of the built-in checkers
+func NewBuiltinCheckerRegistry() *CheckerRegistry {
+	registry, err := NewCheckerRegistry(BuiltinCheckers()...)
+	if err != nil {
+		// The names of the built-in checkers are unique
+		panic(err)
+	}
+
+	return registry
+}
+
+// defaultCheckers is the registry of the built-in checkers used by reconcilers without checkers
+var defaultCheckers = sync.OnceValue(NewBuiltinCheckerRegistry)
+
+// checkSecretName reports TLS blocks without secretName, unless a default certificate is configured
+func checkSecretName(_ context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+	var findings []Finding
+	for _, tlsInstance := range ingress.Spec.TLS {
+		_, ok, err := deps.SecretKey(ingress.Namespace, tlsInstance.SecretName)
+		if !ok {
+			findings = append(findings, NewFinding(ingress, "", ErrSecretNameMissing, nil))
+		} else if err != nil {
+			findings = append(findings, NewFinding(ingress, "", ErrFetchSecret, err))
+		}
+	}
+
+	return findings
+}
+
+// checkCertManager reports the secrets cert-manager fails to issue or renew
+func checkCertManager(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+	var findings []Finding
+	for _, tlsInstance := range ingress.Spec.TLS {
+		// Invalid references are reported by the secret-name checker
+		secretKey, ok, err := deps.SecretKey(ingress.Namespace, tlsInstance.SecretName)
+		if !ok || err != nil {
+			continue
+		}
+
+		// The secret may be missing because cert-manager has not issued it yet
+		_, secret, _, _ := deps.Secret(ctx, ingress, tlsInstance.SecretName)
+
+		certErr, certErrType := certManagerErrorType(ctx, deps.CertManager, ingress, tlsInstance.SecretName, secretKey, secret != nil)
+		if certErrType != nil {
+			findings = append(findings, NewFinding(ingress, "", certErrType, certErr))
+		}
+	}
+
+	return findings
+}
+
+// checkSecret reports secrets which cannot be read or are no TLS secrets with crt and key, see CheckDeps.CertificateOnly
+func checkSecret(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+	var findings []Finding
+	for _, tlsInstance := range ingress.Spec.TLS {
+		_, secret, ok, err := deps.Secret(ctx, ingress, tlsInstance.SecretName)
+		if !ok {
+			continue
+		}
+		if err != nil {
+			findings = append(findings, NewFinding(ingress, "", ErrFetchSecret, err))
+			continue
+		}
+
+		// Only needs TLS secret
+		if !deps.validTLSSecret(secret) {
+			findings = append(findings, NewFinding(ingress, "", ErrCrtOrKeyMissing, nil))
+		}
+	}
+
+	return findings
+}
+
+// checkHosts reports TLS blocks without hosts
+func checkHosts(_ context.Context, ingress *networkingv1.Ingress, _ *CheckDeps) []Finding {
+	var findings []Finding
+	for _, tlsInstance := range ingress.Spec.TLS {
+		if len(tlsInstance.Hosts) == 0 {
+			findings = append(findings, NewFinding(ingress, "", ErrHostsMissing, nil))
+		}
+	}
+
+	return findings
+}
+
+// checkTLSHandshake verifies the TLS of the hosts with the certificate of their secret, block by block.
+// Only the first failed host is reported, the hosts after it are not probed.
+func checkTLSHandshake(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+	for _, tlsInstance := range ingress.Spec.TLS {
+		crt, key, ok := deps.TLSSecret(ctx, ingress, tlsInstance.SecretName)
+		if !ok {
+			continue
+		}
+
+		for _, host := range tlsInstance.Hosts {
+			if err := deps.CheckTLS(ctx, ingress, host, crt, key); err != nil {
+				finding := NewFinding(ingress, host, tlsErrorType(err), err)
+				finding.Fingerprint = utils.Fingerprint(crt)
+				return []Finding{finding}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkCertificateSAN reports hosts not covered by the certificate in the secret.
+// The served certificate may be issued by the one in the secret, which must cover the host itself.
+func checkCertificateSAN(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+	var findings []Finding
+	for _, tlsInstance := range ingress.Spec.TLS {
+		crt, _, ok := deps.TLSSecret(ctx, ingress, tlsInstance.SecretName)
+		if !ok {
+			continue
+		}
+
+		fingerprint := utils.Fingerprint(crt)
+		for _, host := range tlsInstance.Hosts {
+			if err := utils.CertificateCoversHost(crt, host); err != nil {
+				finding := NewFinding(ingress, host, ErrCertificateSANMismatch, err)
+				finding.Fingerprint = fingerprint
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings
+}
+
+// checkHostCoverageFindings reports the first mismatch of the rule hosts and the TLS hosts of an ingress using TLS
+func checkHostCoverageFindings(_ context.Context, ingress *networkingv1.Ingress, _ *CheckDeps) []Finding {
+	if len(ingress.Spec.TLS) == 0 {
+		return nil
+	}
+
+	host, errType := checkHostCoverage(ingress)
+	if errType == nil {
+		return nil
+	}
+
+	return []Finding{NewFinding(ingress, host, errType, nil)}
+}
+
+// checkHTTPRedirect reports ingresses neither using TLS nor redirecting HTTP traffic
+func checkHTTPRedirect(_ context.Context, ingress *networkingv1.Ingress, _ *CheckDeps) []Finding {
+	if len(ingress.Spec.TLS) != 0 || hasHTTPRedirect(ingress) {
+		return nil
+	}
+
+	return []Finding{NewFinding(ingress, "", ErrHTTPRedirectMissing, nil)}
+}
+
+// certManagerErrorType checks the cert-manager Certificate issuing the secret of the ingress.
+// It returns the error of cert-manager and its error type, or nil if the ingress is not
+// annotated for cert-manager, cert-manager is not installed or the Certificate is healthy.
+// The reader c is nil if cert-manager is not installed.
+func certManagerErrorType(
+	ctx context.Context,
+	c client.Reader,
+	ingress *networkingv1.Ingress,
+	secretName string,
+	secretKey types.NamespacedName,
+	secretExists bool,
+) (error, error) {
+	// cert-manager only issues secrets named in the ingress, not the default certificate
+	if c == nil || !certmanager.Manages(ingress.Annotations) || secretName == "" {
+		return nil, nil
+	}
+
+	// The Certificate created by cert-manager for an ingress is named after the secret
+	status, err := certmanager.GetCertificateStatus(ctx, c, secretKey)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "unable to fetch cert-manager certificate", "certificate", secretKey.String())
+		return nil, nil
+	}
+	if status == nil {
+		return nil, nil
+	}
+
+	certErr := errors.New(status.Message)
+	if status.RequestMessage != "" {
+		certErr = errors.New(status.RequestMessage)
+	}
+
+	switch {
+	case !secretExists && status.Failing():
+		return certErr, ErrCertificateIssuanceFailed
+	case !secretExists:
+		return certErr, ErrCertificateIssuancePending
+	case status.Failing():
+		return certErr, ErrCertificateRenewalFailing
+	case !status.Ready:
+		return certErr, ErrCertificateNotReady
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Checker is one rule of the audit pipeline run by the reconciler on every ingress
+type Checker interface {
+	// Name identifies the checker in the flags, logs and metrics
+	Name() string
+	// Reasons returns the codes of the reasons the checker reports
+	Reasons() []string
+	// Check returns the findings of the ingress, none if it passes
+	Check(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding
+}
+
+// CheckDeps are the dependencies of the checkers, created for each reconcile
+type CheckDeps struct {
+	// Client reads the secrets
+	Client client.Reader
+	// CertManager reads the cert-manager resources with certmanager.CertificateNameIndex, nil skips the cert-manager check
+	CertManager client.Reader
+	// SecretKey resolves the secretName of a TLS block in the ingress namespace, see resolveSecretKey
+	SecretKey func(ingressNamespace, secretName string) (types.NamespacedName, bool, error)
+	// CheckTLS verifies the TLS of the host of the ingress with the crt and key of its secret
+	CheckTLS func(ctx context.Context, ingress *networkingv1.Ingress, host string, crt, key []byte) error
+	// CertificateOnly drops the tls.key of the secrets once they are read, the secrets only need a tls.crt
+	CertificateOnly bool
+	// Now is the time the rules are evaluated at, the current time if it is zero
+	Now time.Time
+
+	// secrets caches the secrets of the TLS blocks, so every checker reads them once
+	secrets map[string]secretResult
+	// vars caches the variables of the rule expressions
+	vars map[string]any
+}
+
+// secretResult is a secret of a TLS block read by CheckDeps.Secret
+type secretResult struct {
+	key    types.NamespacedName
+	secret *v1.Secret
+	ok     bool
+	err    error
+}
+
+// Secret resolves and reads the secret of the TLS block of the ingress.
+// The boolean is false if the TLS block references no secret, the error is set if it cannot be resolved or read.
+func (d *CheckDeps) Secret(ctx context.Context, ingress *networkingv1.Ingress, secretName string) (types.NamespacedName, *v1.Secret, bool, error) {
+	if result, ok := d.secrets[secretName]; ok {
+		return result.key, result.secret, result.ok, result.err
+	}
+
+	result := secretResult{}
+	result.key, result.ok, result.err = d.SecretKey(ingress.Namespace, secretName)
+	if result.ok && result.err == nil {
+		secret := &v1.Secret{}
+		if result.err = d.Client.Get(ctx, result.key, secret); result.err == nil {
+			if d.CertificateOnly {
+				delete(secret.Data, v1.TLSPrivateKeyKey)
+			}
+			result.secret = secret
+		}
+	}
+
+	if d.secrets == nil {
+		d.secrets = map[string]secretResult{}
+	}
+	d.secrets[secretName] = result
+
+	return result.key, result.secret, result.ok, result.err
+}
+
+// TLSSecret returns the crt and key of the secret of the TLS block, the boolean is false if it is not a valid TLS secret.
+// With CertificateOnly the key is always nil.
+func (d *CheckDeps) TLSSecret(ctx context.Context, ingress *networkingv1.Ingress, secretName string) ([]byte, []byte, bool) {
+	_, secret, ok, err := d.Secret(ctx, ingress, secretName)
+	if !ok || err != nil || !d.validTLSSecret(secret) {
+		return nil, nil, false
+	}
+
+	return secret.Data[v1.TLSCertKey], secret.Data[v1.TLSPrivateKeyKey], true
+}
+
+// validTLSSecret reports whether the secret is a TLS secret with a crt and, unless CertificateOnly, a key
+func (d *CheckDeps) validTLSSecret(secret *v1.Secret) bool {
+	if secret.Type != v1.SecretTypeTLS || secret.Data[v1.TLSCertKey] == nil {
+		return false
+	}
+
+	return d.CertificateOnly || secret.Data[v1.TLSPrivateKeyKey] != nil
+}
+
+// CheckFunc implements the Check of a checker
+type CheckFunc func(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding
+
+// funcChecker is a Checker implemented by a CheckFunc
+type funcChecker struct {
+	name    string
+	reasons []error
+	check   CheckFunc
+}
+
+// NewChecker creates a checker reporting the error types, which must be in the reason catalog
+func NewChecker(name string, reasons []error, check CheckFunc) Checker {
+	return &funcChecker{name: name, reasons: reasons, check: check}
+}
+
+// Name identifies the checker
+func (c *funcChecker) Name() string {
+	return c.name
+}
+
+// Reasons returns the codes of the reasons of the error types
+func (c *funcChecker) Reasons() []string {
+	codes := make([]string, 0, len(c.reasons))
+	for _, errType := range c.reasons {
+		codes = append(codes, ReasonFor(errType).Code)
+	}
+
+	return codes
+}
+
+// Check runs the CheckFunc
+func (c *funcChecker) Check(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+	return c.check(ctx, ingress, deps)
+}
+
+// NewFinding creates the Error finding of the error type for the ingress.
+// The message is the one of err, or of the error type if err is nil.
+func NewFinding(ingress *networkingv1.Ingress, host string, errType error, err error) Finding {
+	message := errType.Error()
+	if err != nil {
+		message = err.Error()
+	}
+
+	reason := ReasonFor(errType)
+	return Finding{
+		Namespace: ingress.Namespace,
+		Ingress:   ingress.Name,
+		Host:      host,
+		Reason:    reason.Code,
+		Category:  reason.Category,
+		Severity:  ErrLogLevel,
+		Message:   message,
+	}
+}
+
+// CheckerRegistry holds the checkers in the order the reconciler runs them
+type CheckerRegistry struct {
+	checkers []Checker
+	disabled map[string]bool
+}
+
+// NewCheckerRegistry creates a registry of the checkers, all of them enabled
+func NewCheckerRegistry(checkers ...Checker) (*CheckerRegistry, error) {
+	registry := &CheckerRegistry{disabled: map[string]bool{}}
+	for _, checker := range checkers {
+		if err := registry.Register(checker); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// Register appends the checker to the pipeline, its name must be unique
+func (r *CheckerRegistry) Register(checker Checker) error {
+	if r.Get(checker.Name()) != nil {
+		return fmt.Errorf("checker %q is already registered", checker.Name())
+	}
+
+	r.checkers = append(r.checkers, checker)
+	checkerEnabled.WithLabelValues(checker.Name()).Set(1)
+	return nil
+}
+
+// Get returns the checker with the name, or nil if it is not registered
+func (r *CheckerRegistry) Get(name string) Checker {
+	for _, checker := range r.checkers {
+		if checker.Name() == name {
+			return checker
+		}
+	}
+
+	return nil
+}
+
+// SetEnabled enables or disables the checker with the name
+func (r *CheckerRegistry) SetEnabled(name string, enabled bool) error {
+	if r.Get(name) == nil {
+		return fmt.Errorf("unknown checker %q", name)
+	}
+
+	r.disabled[name] = !enabled
+	if enabled {
+		checkerEnabled.WithLabelValues(name).Set(1)
+	} else {
+		checkerEnabled.WithLabelValues(name).Set(0)
+	}
+	return nil
+}
+
+// IsEnabled reports whether the checker with the name is run
+func (r *CheckerRegistry) IsEnabled(name string) bool {
+	return r.Get(name) != nil && !r.disabled[name]
+}
+
+// Checkers returns all registered checkers in their order
+func (r *CheckerRegistry) Checkers() []Checker {
+	return r.checkers
+}
+
+// Enabled returns the enabled checkers in their order
+func (r *CheckerRegistry) Enabled() []Checker {
+	var enabled []Checker
+	for _, checker := range r.checkers {
+		if !r.disabled[checker.Name()] {
+			enabled = append(enabled, checker)
+		}
+	}
+
+	return enabled
+}
+
+// Run runs the enabled checkers in order and returns the findings of the first one failing the ingress.
+// The later checkers are skipped, so e.g. a missing secret is not also reported as a failed handshake.
+func (r *CheckerRegistry) Run(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+	for _, checker := range r.Enabled() {
+		start := time.Now()
+		findings := checker.Check(ctx, ingress, deps)
+		checkerDuration.WithLabelValues(checker.Name()).Observe(time.Since(start).Seconds())
+
+		for _, finding := range findings {
+			checkerFindings.WithLabelValues(checker.Name(), finding.Reason).Inc()
+		}
+		if len(findings) != 0 {
+			return findings
+		}
+	}
+
+	return nil
+}
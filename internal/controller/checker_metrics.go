@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Metrics of the checkers, served on the metrics endpoint of the manager
+var (
+	checkerEnabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ingress_audit_checker_enabled",
+		Help: "Whether the checker is run by the reconciler (1) or disabled (0).",
+	}, []string{"checker"})
+
+	checkerFindings = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ingress_audit_checker_findings_total",
+		Help: "Number of findings reported by the checker, by reason.",
+	}, []string{"checker", "reason"})
+
+	checkerDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ingress_audit_checker_duration_seconds",
+		Help:    "Duration of the checks of an ingress by the checker.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 4, 8),
+	}, []string{"checker"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(checkerEnabled, checkerFindings, checkerDuration)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+var _ = Describe("Checkers", func() {
+	ctx := context.Background()
+
+	tlsIngress := func(secretName string, hosts ...string) *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+			Spec: networkingv1.IngressSpec{
+				TLS: []networkingv1.IngressTLS{{SecretName: secretName, Hosts: hosts}},
+			},
+		}
+	}
+
+	newDeps := func(checkTLS func(host string) error, objects ...client.Object) *CheckDeps {
+		return &CheckDeps{
+			Client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objects...).Build(),
+			SecretKey: func(ingressNamespace, secretName string) (types.NamespacedName, bool, error) {
+				return resolveSecretKey(ingressNamespace, secretName, false, nil)
+			},
+			CheckTLS: func(_ context.Context, _ *networkingv1.Ingress, host string, _, _ []byte) error {
+				return checkTLS(host)
+			},
+		}
+	}
+
+	tlsSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tls"},
+		Type:       v1.SecretTypeTLS,
+		Data:       map[string][]byte{v1.TLSCertKey: []byte("crt"), v1.TLSPrivateKeyKey: []byte("key")},
+	}
+	passing := func(string) error { return nil }
+
+	Context("Registry", func() {
+		failing := NewChecker("failing", []error{ErrHostsMissing}, func(_ context.Context, ingress *networkingv1.Ingress, _ *CheckDeps) []Finding {
+			return []Finding{NewFinding(ingress, "", ErrHostsMissing, nil)}
+		})
+		var ran bool
+		recording := NewChecker("recording", nil, func(context.Context, *networkingv1.Ingress, *CheckDeps) []Finding {
+			ran = true
+			return nil
+		})
+
+		BeforeEach(func() {
+			ran = false
+		})
+
+		It("should reject duplicate and unknown checkers", func() {
+			registry, err := NewCheckerRegistry(failing)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.Register(failing)).NotTo(Succeed())
+			Expect(registry.SetEnabled("unknown", false)).NotTo(Succeed())
+		})
+
+		It("should stop at the first checker reporting findings", func() {
+			registry, err := NewCheckerRegistry(failing, recording)
+			Expect(err).NotTo(HaveOccurred())
+
+			findings := registry.Run(ctx, tlsIngress("tls"), nil)
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Reason).To(Equal("HostsMissing"))
+			Expect(ran).To(BeFalse())
+		})
+
+		It("should skip disabled checkers", func() {
+			registry, err := NewCheckerRegistry(failing, recording)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.SetEnabled("failing", false)).To(Succeed())
+			Expect(registry.IsEnabled("failing")).To(BeFalse())
+
+			Expect(registry.Run(ctx, tlsIngress("tls"), nil)).To(BeEmpty())
+			Expect(ran).To(BeTrue())
+		})
+
+		It("should list the reason codes of the checker", func() {
+			Expect(failing.Reasons()).To(ConsistOf("HostsMissing"))
+		})
+	})
+
+	Context("Built-in checkers", func() {
+		It("should have unique names with known reasons", func() {
+			registry := NewBuiltinCheckerRegistry()
+			Expect(registry.Checkers()).To(HaveLen(len(BuiltinCheckers())))
+			for _, checker := range registry.Checkers() {
+				for _, code := range checker.Reasons() {
+					_, ok := errTypeByCode(code)
+					Expect(ok).To(BeTrue(), code)
+				}
+			}
+		})
+
+		It("should report a TLS block without secretName", func() {
+			findings := checkSecretName(ctx, tlsIngress("", "foo.com"), newDeps(passing))
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Reason).To(Equal(ReasonFor(ErrSecretNameMissing).Code))
+		})
+
+		It("should report a missing secret and a secret without crt", func() {
+			findings := checkSecret(ctx, tlsIngress("tls", "foo.com"), newDeps(passing))
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Reason).To(Equal(ReasonFor(ErrFetchSecret).Code))
+
+			opaque := tlsSecret.DeepCopy()
+			opaque.Type = v1.SecretTypeOpaque
+			findings = checkSecret(ctx, tlsIngress("tls", "foo.com"), newDeps(passing, opaque))
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Reason).To(Equal(ReasonFor(ErrCrtOrKeyMissing).Code))
+		})
+
+		It("should report a TLS block without hosts", func() {
+			Expect(checkHosts(ctx, tlsIngress("tls"), nil)).To(HaveLen(1))
+			Expect(checkHosts(ctx, tlsIngress("tls", "foo.com"), nil)).To(BeEmpty())
+		})
+
+		It("should classify the failed handshakes of the hosts", func() {
+			checkTLS := func(host string) error {
+				if host == "bar.com" {
+					return fmt.Errorf("failed to connect: %w", utils.ErrHostnameMismatch)
+				}
+				return nil
+			}
+
+			findings := checkTLSHandshake(ctx, tlsIngress("tls", "foo.com", "bar.com"), newDeps(checkTLS, tlsSecret))
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Host).To(Equal("bar.com"))
+			Expect(findings[0].Reason).To(Equal(ReasonFor(ErrHostnameMismatch).Code))
+			Expect(findings[0].Message).To(ContainSubstring("failed to connect"))
+		})
+
+		It("should stop at the first failed host in the order of the TLS blocks", func() {
+			var probed []string
+			failing := func(host string) error {
+				probed = append(probed, host)
+				if host == "foo.com" {
+					return nil
+				}
+				return fmt.Errorf("failed to connect: %w", utils.ErrHostnameMismatch)
+			}
+			ingress := tlsIngress("tls", "foo.com", "bar.com")
+			ingress.Spec.TLS = append(ingress.Spec.TLS, networkingv1.IngressTLS{SecretName: "tls", Hosts: []string{"baz.com"}})
+
+			findings := checkTLSHandshake(ctx, ingress, newDeps(failing, tlsSecret))
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Host).To(Equal("bar.com"))
+			Expect(probed).To(Equal([]string{"foo.com", "bar.com"}))
+		})
+
+		It("should check the certificate SAN before the handshake", func() {
+			var probed []string
+			failing := func(host string) error {
+				probed = append(probed, host)
+				return utils.ErrHandshake
+			}
+			secret := tlsSecret.DeepCopy()
+			secret.Data[v1.TLSCertKey] = newTestCertificate(time.Now().Add(time.Hour), "bar.com")
+
+			findings := NewBuiltinCheckerRegistry().Run(ctx, tlsIngress("tls", "foo.com"), newDeps(failing, secret))
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Reason).To(Equal(ReasonFor(ErrCertificateSANMismatch).Code))
+			Expect(probed).To(BeEmpty())
+		})
+
+		It("should not verify the handshake without a valid secret", func() {
+			failing := func(string) error { return utils.ErrHandshake }
+			Expect(checkTLSHandshake(ctx, tlsIngress("tls", "foo.com"), newDeps(failing))).To(BeEmpty())
+		})
+
+		It("should report an ingress without TLS and redirect", func() {
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"}}
+			Expect(checkHTTPRedirect(ctx, ingress, nil)).To(HaveLen(1))
+
+			ingress.Annotations = map[string]string{"nginx.ingress.kubernetes.io/permanent-redirect": "https://foo.com"}
+			Expect(checkHTTPRedirect(ctx, ingress, nil)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+)
+
+// logDryRun logs the ingresstlslogs instance the reconciler would have created in a dry run
+func logDryRun(log logr.Logger, TLSLog *ingressauditv1alpha1.IngressTLSLog) {
+	log.Info("Dry run, not creating the ingress TLS log",
+		"namespace", TLSLog.Spec.NameSpace,
+		"ingress", TLSLog.Spec.IngressName,
+		"host", TLSLog.Spec.Host,
+		"reason", TLSLog.Spec.Reason,
+		"severity", TLSLog.Spec.LogLevel,
+		"message", TLSLog.Spec.Message)
+}
+
+// logDryRunSummary logs the summary of the open findings after every interval until ctx is done
+func (r *IngressTLSLogReconciler) logDryRunSummary(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("dry-run")
+
+	interval := r.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			summary := SummarizeFindings(r.OpenFindings())
+			log.Info("Dry run summary of the open findings",
+				"total", summary.Total,
+				"byReason", summary.ByReason,
+				"bySeverity", summary.BySeverity,
+				"byNamespace", summary.ByNamespace)
+		}
+	}
+}
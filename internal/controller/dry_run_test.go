@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/store"
+)
+
+var _ = Describe("Dry run", func() {
+	It("should record the findings without creating ingress TLS logs", func() {
+		testScheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+		Expect(ingressauditv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", UID: "uid"}}
+		r := &IngressTLSLogReconciler{
+			Client:               fake.NewClientBuilder().WithScheme(testScheme).WithObjects(ingress).Build(),
+			Scheme:               testScheme,
+			IngressErrorMap:      store.NewIngressErrorMap(),
+			IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
+			IngressFindingMap:    store.NewIngressFindingMap(),
+			DryRun:               true,
+		}
+		ctx := context.Background()
+
+		Expect(r.logErrorAndUpdateMaps(ctx, ingress, "ns", "web", findingDetail{}, ErrHTTPRedirectMissing, "ns/web")).To(Succeed())
+
+		logs := &ingressauditv1alpha1.IngressTLSLogList{}
+		Expect(r.List(ctx, logs)).To(Succeed())
+		Expect(logs.Items).To(BeEmpty())
+
+		summary := SummarizeFindings(r.OpenFindings())
+		Expect(summary.Total).To(Equal(1))
+		Expect(summary.ByReason).To(HaveKeyWithValue(ReasonFor(ErrHTTPRedirectMissing).Code, 1))
+	})
+})
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/store"
+)
+
+// nextFinding returns the finding the error type of the ingress is logged as at now.
+// A different error type than the recorded one starts a new finding.
+// Only findings the checker reports as Error are escalated, other severities are kept.
+func (r *IngressTLSLogReconciler) nextFinding(key string, errType error, severity string, now time.Time) store.Finding {
+	fixed := severity != "" && severity != ErrLogLevel
+	if r.Escalation == nil {
+		if fixed {
+			return store.Finding{FirstSeen: now, Severity: severity, FixedSeverity: true}
+		}
+		return store.Finding{FirstSeen: now, Severity: ErrLogLevel}
+	}
+
+	var finding store.Finding
+	ok := false
+	if r.IngressFindingMap != nil {
+		finding, ok = r.IngressFindingMap.Get(key)
+	}
+	recorded, exist := r.IngressErrorMap.Get(key)
+	if !ok || !exist || recorded != errType {
+		finding = store.Finding{FirstSeen: now}
+	} else {
+		finding.Repeats++
+	}
+
+	finding.FixedSeverity = fixed
+	if fixed {
+		finding.Severity = severity
+	} else {
+		finding.Severity = r.Escalation.Severity(now.Sub(finding.FirstSeen), r.Interval)
+	}
+	return finding
+}
+
+// renotifyDue reports whether the recorded error of the ingress, last logged at lastUpdateTime, is logged again at now
+func (r *IngressTLSLogReconciler) renotifyDue(key string, lastUpdateTime, now time.Time) bool {
+	if r.Escalation == nil {
+		return !now.Before(lastUpdateTime.Add(r.Interval))
+	}
+
+	if r.IngressFindingMap == nil {
+		return !now.Before(lastUpdateTime.Add(r.Interval))
+	}
+	finding, ok := r.IngressFindingMap.Get(key)
+	if !ok {
+		return true
+	}
+
+	// An escalated finding is logged right away, regardless of the backoff
+	if !finding.FixedSeverity && r.Escalation.Severity(now.Sub(finding.FirstSeen), r.Interval) != finding.Severity {
+		return true
+	}
+
+	return !now.Before(lastUpdateTime.Add(r.Escalation.RenotifyInterval(finding.Repeats, r.Interval)))
+}
+
+// forgetFinding removes the recorded error of the ingress from the stores
+func (r *IngressTLSLogReconciler) forgetFinding(key string) {
+	r.IngressErrorMap.Delete(key)
+	if r.IngressFindingMap != nil {
+		r.IngressFindingMap.Delete(key)
+	}
+}
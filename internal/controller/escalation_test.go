@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+	"github.com/MMMMMMorty/ingress-auditor/internal/store"
+)
+
+var _ = Describe("Escalation", func() {
+	It("should start a new finding without a finding map", func() {
+		r := &IngressTLSLogReconciler{
+			IngressErrorMap: store.NewIngressErrorMap(),
+			Escalation:      &policy.Escalation{EscalateAfterIntervals: 2},
+			Interval:        time.Hour,
+		}
+		now := time.Now()
+		r.IngressErrorMap.Set("ns/web", ErrHTTPRedirectMissing)
+
+		finding := r.nextFinding("ns/web", ErrHTTPRedirectMissing, ErrLogLevel, now)
+		Expect(finding.FirstSeen).To(Equal(now))
+		Expect(finding.Repeats).To(BeZero())
+		Expect(finding.Severity).To(Equal(WarnLogLevel))
+
+		Expect(r.renotifyDue("ns/web", now.Add(-30*time.Minute), now)).To(BeFalse())
+		Expect(r.renotifyDue("ns/web", now.Add(-time.Hour), now)).To(BeTrue())
+	})
+})
@@ -0,0 +1,43 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// ExemptUntilAnnotation exempts the ingress from the audit until the RFC 3339 time in its value
+const ExemptUntilAnnotation = "ingress-audit.morty.dev/exempt-until"
+
+// ExemptUntil returns until when the ingress is exempted from the audit.
+// The boolean is false if the ingress is not annotated.
+func ExemptUntil(ingress *networkingv1.Ingress) (time.Time, bool, error) {
+	value, ok := ingress.Annotations[ExemptUntilAnnotation]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s annotation %q: %w", ExemptUntilAnnotation, value, err)
+	}
+
+	return until, true, nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+var _ = Describe("Exemption", func() {
+	newIngress := func(annotations map[string]string) *networkingv1.Ingress {
+		ingress := &networkingv1.Ingress{}
+		ingress.Annotations = annotations
+		return ingress
+	}
+
+	It("should parse the exempt-until annotation", func() {
+		until, ok, err := ExemptUntil(newIngress(map[string]string{ExemptUntilAnnotation: "2025-12-12T00:00:00Z"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(until).To(Equal(time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should not exempt ingresses without the annotation", func() {
+		_, ok, err := ExemptUntil(newIngress(nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should reject an invalid time", func() {
+		_, ok, err := ExemptUntil(newIngress(map[string]string{ExemptUntilAnnotation: "tomorrow"}))
+		Expect(err).To(MatchError(ContainSubstring("invalid " + ExemptUntilAnnotation)))
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+// checkHostCoverage cross-references the rule hosts and the TLS hosts of an ingress using TLS.
+// It returns the first host that is routed but not TLS protected, or TLS protected but not routed,
+// and its error type. The host is empty for an ingress only defining a default backend.
+func checkHostCoverage(ingress *networkingv1.Ingress) (string, error) {
+	if len(ingress.Spec.Rules) == 0 {
+		if ingress.Spec.DefaultBackend != nil {
+			return "", ErrDefaultBackendOnly
+		}
+		return "", nil
+	}
+
+	var tlsHosts []string
+	for _, tlsInstance := range ingress.Spec.TLS {
+		tlsHosts = append(tlsHosts, tlsInstance.Hosts...)
+	}
+
+	// A rule without host routes every host, including all TLS hosts
+	catchAll := false
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" {
+			catchAll = true
+			continue
+		}
+
+		if !matchesAny(tlsHosts, rule.Host) {
+			return rule.Host, ErrRuleHostNotCovered
+		}
+	}
+
+	if catchAll {
+		return "", nil
+	}
+
+	for _, tlsHost := range tlsHosts {
+		routed := false
+		for _, rule := range ingress.Spec.Rules {
+			if utils.HostMatches(tlsHost, rule.Host) || utils.HostMatches(rule.Host, tlsHost) {
+				routed = true
+				break
+			}
+		}
+
+		if !routed {
+			return tlsHost, ErrTLSHostWithoutRule
+		}
+	}
+
+	return "", nil
+}
+
+// matchesAny reports whether the host is matched by any of the patterns
+func matchesAny(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if utils.HostMatches(pattern, host) {
+			return true
+		}
+	}
+
+	return false
+}
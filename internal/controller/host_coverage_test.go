@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+var _ = Describe("Host coverage", func() {
+	newIngress := func(tlsHosts []string, ruleHosts ...string) *networkingv1.Ingress {
+		ingress := &networkingv1.Ingress{}
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: tlsHosts, SecretName: "secret-tls"}}
+		for _, host := range ruleHosts {
+			ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{Host: host})
+		}
+		return ingress
+	}
+
+	DescribeTable("should cross-reference the rule hosts and TLS hosts",
+		func(ingress *networkingv1.Ingress, expectedHost string, expectedErr error) {
+			host, errType := checkHostCoverage(ingress)
+			Expect(host).To(Equal(expectedHost))
+			if expectedErr == nil {
+				Expect(errType).NotTo(HaveOccurred())
+			} else {
+				Expect(errType).To(Equal(expectedErr))
+			}
+		},
+		Entry("all hosts covered", newIngress([]string{"a.foo.com"}, "a.foo.com"), "", nil),
+		Entry("rule host covered by a wildcard", newIngress([]string{"*.foo.com"}, "a.foo.com"), "", nil),
+		Entry("rule host not TLS protected",
+			newIngress([]string{"a.foo.com"}, "a.foo.com", "b.foo.com"), "b.foo.com", ErrRuleHostNotCovered),
+		Entry("wildcard only covers one label",
+			newIngress([]string{"*.foo.com"}, "a.b.foo.com"), "a.b.foo.com", ErrRuleHostNotCovered),
+		Entry("TLS host not routed",
+			newIngress([]string{"a.foo.com", "typo.foo.com"}, "a.foo.com"), "typo.foo.com", ErrTLSHostWithoutRule),
+		Entry("TLS host routed by a catch-all rule", newIngress([]string{"a.foo.com"}, ""), "", nil),
+		Entry("default backend only", func() *networkingv1.Ingress {
+			ingress := newIngress([]string{"a.foo.com"})
+			ingress.Spec.DefaultBackend = &networkingv1.IngressBackend{}
+			return ingress
+		}(), "", ErrDefaultBackendOnly),
+	)
+})
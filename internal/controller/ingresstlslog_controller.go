@@ -20,18 +20,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"sync"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/MMMMMMorty/ingress-auditor/internal/certmanager"
+	"github.com/MMMMMMorty/ingress-auditor/internal/notify"
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+	"github.com/MMMMMMorty/ingress-auditor/internal/prober"
 	"github.com/MMMMMMorty/ingress-auditor/internal/store"
 	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
 	"github.com/google/uuid"
@@ -40,6 +49,16 @@ import (
 	"github.com/go-logr/logr"
 )
 
+// findingDetail describes what a logged error is about
+type findingDetail struct {
+	// host is the ingress host, empty if the error is about the whole ingress
+	host string
+	// fingerprint is the fingerprint of the certificate in the secret, empty if it was not read
+	fingerprint string
+	// severity is the severity reported by the checker, only Error is escalated
+	severity string
+}
+
 // IngressTLSLogReconciler reconciles a IngressTLSLog object
 type IngressTLSLogReconciler struct {
 	client.Client
@@ -54,10 +73,82 @@ type IngressTLSLogReconciler struct {
 
 	// IngressUpdateTimeMap records the update time of the ingress
 	IngressUpdateTimeMap *store.IngressUpdateTimeMap
+
+	// IngressFindingMap records the age and repetitions of the error of each ingress, it is required by Escalation
+	IngressFindingMap *store.IngressFindingMap
+
+	// Escalation configures the severity and re-notification backoff of persisting errors, its quiet hours are
+	// applied by the notification sinks of Notifier, see notify.QuietSink. If it is nil, every error is logged as Error and logged again after each interval
+	Escalation *policy.Escalation
+
+	// Probe configures the address and port dialed for the TLS verification
+	Probe ProbeConfig
+
+	// Prober runs the TLS verification on a shared worker pool with caching and rate limiting.
+	// If it is nil, the TLS verification runs directly in the reconcile
+	Prober *prober.Prober
+
+	// MaxConcurrentReconciles is the maximum number of ingresses reconciled at the same time
+	MaxConcurrentReconciles int
+
+	// AllowCrossNamespaceSecrets parses secretName as namespace/name for ingress controllers supporting it
+	AllowCrossNamespaceSecrets bool
+
+	// DefaultCertificate is the secret served by the ingress controller for TLS blocks without secretName.
+	// If it is nil, those TLS blocks are logged as ErrSecretNameMissing
+	DefaultCertificate *types.NamespacedName
+
+	// Notifier sends every newly logged error to the configured sinks, nothing is sent if it is nil
+	Notifier *notify.Dispatcher
+
+	// LogMode is LogModeAppend or LogModeUpsert, an empty mode appends
+	LogMode string
+
+	// Checkers are run on every ingress in order, the built-in checkers are used if it is nil
+	Checkers *CheckerRegistry
+
+	// DryRun runs all checks but only records the findings in the stores, logs and metrics.
+	// No ingresstlslogs instances are created and no notifications are sent
+	DryRun bool
+
+	// MetadataOnlySecrets only watches the metadata of the secrets, the secrets referenced by the ingresses
+	// are read from the API server by APIReader. The client must not cache the secrets
+	MetadataOnlySecrets bool
+
+	// APIReader reads the secrets from the API server if MetadataOnlySecrets is set
+	APIReader client.Reader
+
+	// SecretMetadataReader reads the metadata of the secrets from the cache of the manager if MetadataOnlySecrets is set,
+	// the client does not cache the secrets, so it would read their metadata from the API server too
+	SecretMetadataReader client.Reader
+
+	// CertManagerReader reads the cert-manager resources with certmanager.CertificateNameIndex, SetupWithManager
+	// sets it to the cache of the manager if cert-manager is installed. The cert-manager check is skipped if it is nil
+	CertManagerReader client.Reader
+
+	// CertificateOnly never uses the tls.key of the secrets, it is dropped once a secret is read
+	// and the secrets are only required to hold a tls.crt. The API server still sends the key with the secret
+	CertificateOnly bool
+
+	// AuditStatus keeps an ingressauditstatuses instance per ingress in sync on every reconcile, except in a dry run
+	AuditStatus bool
+
+	// AnnotateIngresses writes the audit summary back to the ingresses as annotations, except in a dry run
+	AnnotateIngresses bool
+
+	// ExpiryWarning sets the ExpiryOK condition of the ingressauditstatuses instances to false for certificates
+	// expiring within this duration, in default is 30 days
+	ExpiryWarning time.Duration
+
+	// certificates are the secrets read from the API server with MetadataOnlySecrets, without their tls.key,
+	// by types.NamespacedName. They are reused while their resourceVersion is unchanged, see certificateReader
+	certificates sync.Map
 }
 
 const (
-	ErrLogLevel = "Error"
+	ErrLogLevel  = "Error"
+	WarnLogLevel = "Warn"
+	InfoLogLevel = "Info"
 )
 
 var ErrFetchIngress = errors.New("unable to fetch ingress")
@@ -69,11 +160,54 @@ var ErrTLSVerification = errors.New("TLS verification failed")
 var ErrHTTPRedirectMissing = errors.New("TLS is not used and redirect is not applied neither")
 var ErrCreateTLSLog = errors.New("failed to create new TLS log")
 
+// The cert-manager failures of the certificates issued for the ingress
+var ErrCertificateIssuancePending = errors.New("the secret is missing because cert-manager is still issuing it")
+var ErrCertificateIssuanceFailed = errors.New("the secret is missing because cert-manager failed to issue it")
+var ErrCertificateNotReady = errors.New("the cert-manager Certificate is not ready")
+var ErrCertificateRenewalFailing = errors.New("cert-manager failed to renew the certificate")
+
+// The coverage failures between the rule hosts, TLS hosts and certificates
+var ErrCertificateSANMismatch = errors.New("the certificate in the secret does not cover the host")
+var ErrRuleHostNotCovered = errors.New("the host is routed but not covered by any TLS block")
+var ErrTLSHostWithoutRule = errors.New("the TLS host is not routed by any rule")
+var ErrDefaultBackendOnly = errors.New("the ingress only defines a default backend without hosts")
+
+// ErrCertificateExpiringSoon is only reported by the static checks, the TLS verification fails once it expires
+var ErrCertificateExpiringSoon = errors.New("the certificate in the secret expires soon")
+
+// The TLS verification failures classified by the probe
+var ErrDNSResolution = fmt.Errorf("%w: %w", ErrTLSVerification, utils.ErrDNSResolution)
+var ErrConnectionRefused = fmt.Errorf("%w: %w", ErrTLSVerification, utils.ErrConnectionRefused)
+var ErrConnectionTimeout = fmt.Errorf("%w: %w", ErrTLSVerification, utils.ErrConnectionTimeout)
+var ErrNetworkUnreachable = fmt.Errorf("%w: %w", ErrTLSVerification, utils.ErrNetworkUnreachable)
+var ErrTLSHandshake = fmt.Errorf("%w: %w", ErrTLSVerification, utils.ErrHandshake)
+var ErrHostnameMismatch = fmt.Errorf("%w: %w", ErrTLSVerification, utils.ErrHostnameMismatch)
+var ErrCertificateExpired = fmt.Errorf("%w: %w", ErrTLSVerification, utils.ErrCertificateExpired)
+var ErrCertificateNotYetValid = fmt.Errorf("%w: %w", ErrTLSVerification, utils.ErrCertificateNotYetValid)
+var ErrUnknownAuthority = fmt.Errorf("%w: %w", ErrTLSVerification, utils.ErrUnknownAuthority)
+
+// tlsErrorTypes maps the failures classified by the probe to the logged error types
+var tlsErrorTypes = map[error]error{
+	utils.ErrDNSResolution:          ErrDNSResolution,
+	utils.ErrConnectionRefused:      ErrConnectionRefused,
+	utils.ErrConnectionTimeout:      ErrConnectionTimeout,
+	utils.ErrNetworkUnreachable:     ErrNetworkUnreachable,
+	utils.ErrHandshake:              ErrTLSHandshake,
+	utils.ErrHostnameMismatch:       ErrHostnameMismatch,
+	utils.ErrCertificateExpired:     ErrCertificateExpired,
+	utils.ErrCertificateNotYetValid: ErrCertificateNotYetValid,
+	utils.ErrUnknownAuthority:       ErrUnknownAuthority,
+}
+
 // +kubebuilder:rbac:groups=ingress-audit.morty.dev,resources=ingresstlslogs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ingress-audit.morty.dev,resources=ingresstlslogs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ingress-audit.morty.dev,resources=ingresstlslogs/finalizers,verbs=update
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ingress-audit.morty.dev,resources=ingressauditstatuses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ingress-audit.morty.dev,resources=ingressauditstatuses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ingress-audit.morty.dev,resources=ingressauditstatuses/finalizers,verbs=update
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;certificaterequests,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -94,89 +228,135 @@ func (r *IngressTLSLogReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	ingressNs := req.Namespace
 
 	err := r.Get(ctx, req.NamespacedName, ingress)
+	if apierrors.IsNotFound(err) {
+		// The ingress is deleted, its logs are garbage collected through the owner reference
+		r.forgetIngress(ingressNamespacedName)
+		log.V(1).Info(fmt.Sprintf("Ingress %s is deleted", ingressNamespacedName))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		// The ingress is unknown, so the error cannot be logged for it and the request is retried
+		log.Error(err, ErrFetchIngress.Error())
+		return ctrl.Result{}, fmt.Errorf("%w: %w", ErrFetchIngress, err)
+	}
+
+	// Skip the audit while the ingress is exempted
+	until, exempted, err := ExemptUntil(ingress)
 	if err != nil {
-		_, exist := r.IngressErrorMap.Get(ingressNamespacedName)
-		if exist {
-			// delete the key-value in map
-			r.IngressErrorMap.Delete(ingressNamespacedName)
+		log.Error(err, "ignoring the exemption of the ingress")
+	}
+	if exempted && time.Now().Before(until) {
+		log.Info(fmt.Sprintf("Ingress %s is exempted until %s", ingressNamespacedName, until.Format(time.RFC3339)))
+		return ctrl.Result{RequeueAfter: min(r.Interval, time.Until(until))}, nil
+	}
+
+	// Run the checkers, the first finding is logged
+	deps := r.checkDeps(log)
+	findings := r.checkers().Run(ctx, ingress, deps)
+
+	if r.AuditStatus && !r.DryRun {
+		if err := r.syncAuditStatus(ctx, ingress, findings, deps); err != nil {
+			log.Error(err, "unable to update the audit status of the ingress")
+		}
+	}
+
+	if r.AnnotateIngresses && !r.DryRun {
+		if err := r.annotateIngress(ctx, ingress, findings, deps); err != nil {
+			log.Error(err, "unable to annotate the ingress")
+		}
+	}
+
+	for _, finding := range findings {
+		errType, ok := errTypeByCode(finding.Reason)
+		if !ok {
+			log.Error(nil, "ignoring the finding of an unknown reason", "reason", finding.Reason)
+			continue
+		}
+
+		var err error
+		if finding.Message != errType.Error() {
+			err = errors.New(finding.Message)
 		}
 
-		return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, err, ErrFetchIngress, log)
+		detail := findingDetail{host: finding.Host, fingerprint: finding.Fingerprint, severity: finding.Severity}
+		return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, detail, err, errType, log)
 	}
 
-	// Check if TLS exists
-	// If yes
 	if len(ingress.Spec.TLS) != 0 {
-		// Check if TLS secret exists
-		for _, tlsInstance := range ingress.Spec.TLS {
-			// Get the secretName
-			if tlsInstance.SecretName == "" {
-				return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, nil, ErrSecretNameMissing, log)
-			}
+		log.Info(fmt.Sprintf("Ingress %s TLS ia applied correctly", ingressNamespacedName))
+	} else {
+		log.Info(fmt.Sprintf("Ingress %s TLS is not used but redirect is applied", ingressNamespacedName))
+	}
 
-			// Fetch the secret
-			secret := &v1.Secret{}
-			err = r.Get(ctx, types.NamespacedName{Name: tlsInstance.SecretName, Namespace: ingress.Namespace}, secret)
-			if err != nil {
-				return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, err, ErrFetchSecret, log)
-			}
+	r.resolveIngressError(ingressNs, ingressName, ingressNamespacedName)
 
-			// Only needs TLS secret
-			if secret.Type != v1.SecretTypeTLS {
-				return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, nil, ErrCrtOrKeyMissing, log)
-			}
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
 
-			// Get crt and key
-			crt := secret.Data[v1.TLSCertKey]
-			key := secret.Data[v1.TLSPrivateKeyKey]
+// resolveIngressError forgets the logged error of an ingress passing all checks and notifies its resolution
+func (r *IngressTLSLogReconciler) resolveIngressError(ingressNs, ingressName, ingressNamespacedName string) {
+	errType, exist := r.IngressErrorMap.Get(ingressNamespacedName)
+	if !exist {
+		return
+	}
 
-			if crt == nil || key == nil {
-				return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, nil, ErrCrtOrKeyMissing, log)
-			}
+	r.forgetFinding(ingressNamespacedName)
+
+	if r.Notifier != nil && !r.DryRun {
+		reason := ReasonFor(errType)
+		r.Notifier.Notify(notify.Event{
+			Type:      notify.EventResolved,
+			Timestamp: time.Now(),
+			Namespace: ingressNs,
+			Ingress:   ingressName,
+			Reason:    reason.Code,
+			Category:  reason.Category,
+			Severity:  InfoLogLevel,
+			Message:   errType.Error(),
+		})
+	}
+}
 
-			// Get the hosts
-			if len(tlsInstance.Hosts) == 0 {
-				return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, nil, ErrHostsMissing, log)
-			}
+// checkTLS verifies the TLS of the target through the shared prober if it is configured
+func (r *IngressTLSLogReconciler) checkTLS(ctx context.Context, log logr.Logger, crt, key []byte, target utils.ProbeTarget) error {
+	if r.Prober == nil {
+		return utils.CheckTLS(log, crt, key, target)
+	}
 
-			// For all the hosts, use openssl verifies it
-			for _, host := range tlsInstance.Hosts {
-				err = utils.CheckTLS(log, crt, key, host)
-				if err != nil {
-					return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, err, ErrTLSVerification, log)
-				}
-			}
+	return r.Prober.Probe(ctx, log, crt, key, target)
+}
 
-			log.Info(fmt.Sprintf("Ingress %s TLS ia applied correctly", ingressNamespacedName))
-		}
-	} else {
-		// If not, check if redirect exist.
-		if len(ingress.Annotations) == 0 {
-			return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, nil, ErrHTTPRedirectMissing, log)
-		}
+// checkDeps returns the dependencies of the checkers for one reconcile
+func (r *IngressTLSLogReconciler) checkDeps(log logr.Logger) *CheckDeps {
+	return &CheckDeps{
+		Client:          r.secretReader(),
+		CertManager:     r.CertManagerReader,
+		SecretKey:       r.secretKey,
+		CertificateOnly: r.CertificateOnly,
+		CheckTLS: func(ctx context.Context, ingress *networkingv1.Ingress, host string, crt, key []byte) error {
+			return r.checkTLS(ctx, log, crt, key, r.Probe.targetFor(ingress, host))
+		},
+	}
+}
 
-		redirect := false
-		for key, value := range ingress.Annotations {
-			if strings.Contains(key, "permanent-redirect") || strings.Contains(key, "temporary-redirect") {
-				redirect = true
-				break
-			}
+// checkers returns the checkers of the reconciler, or the built-in ones if none are configured
+func (r *IngressTLSLogReconciler) checkers() *CheckerRegistry {
+	if r.Checkers == nil {
+		return defaultCheckers()
+	}
 
-			if strings.Contains(key, "configuration-snippet") &&
-				(strings.Contains(value, "301") || strings.Contains(value, "302")) {
-				redirect = true
-				break
-			}
-		}
+	return r.Checkers
+}
 
-		if !redirect {
-			return r.handleIngressError(ctx, ingress, ingressNs, ingressName, ingressNamespacedName, nil, ErrHTTPRedirectMissing, log)
+// tlsErrorType returns the error type of the failed TLS verification
+func tlsErrorType(err error) error {
+	for probeErr, errType := range tlsErrorTypes {
+		if errors.Is(err, probeErr) {
+			return errType
 		}
-
-		log.Info(fmt.Sprintf("Ingress %s TLS is not used but redirect is applied", ingressNamespacedName))
 	}
 
-	return ctrl.Result{RequeueAfter: r.Interval}, nil
+	return ErrTLSVerification
 }
 
 // checkKeyValue checks if the given key exists in the map and has the specified value.
@@ -196,8 +376,8 @@ func (r *IngressTLSLogReconciler) checkKeyValue(key string, value error) bool {
 		return false
 	}
 
-	// If lastUpdateTime + interval > now, return True; vice versa
-	return time.Now().Before(lastUpdateTime.Add(r.Interval))
+	// If the error is not due to be logged again, return True; vice versa
+	return !r.renotifyDue(key, lastUpdateTime, time.Now())
 }
 
 // logErrorAndUpdateMaps updates maps of IngressUpdateTimeMap and IngressErrorMap
@@ -207,22 +387,25 @@ func (r *IngressTLSLogReconciler) updateValueForKey(key string, err error, updat
 }
 
 // createTLSLog creates ingresstlslogs instance
-func (r *IngressTLSLogReconciler) createTLSLog(ingress *networkingv1.Ingress, ingressNamespace string, ingressName string, err error, updateTime time.Time) (*ingressauditv1alpha1.IngressTLSLog, error) {
+func (r *IngressTLSLogReconciler) createTLSLog(ingress *networkingv1.Ingress, ingressNamespace string, ingressName string, detail findingDetail, level string, err error, updateTime time.Time) (*ingressauditv1alpha1.IngressTLSLog, error) {
 	timeStr := updateTime.Format("2006-01-02-15-04-05")
 	uniqueSuffix := uuid.NewString()[:8] // random 8-digit number
 	TLSLog := &ingressauditv1alpha1.IngressTLSLog{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-%s-%s-%s", ingressNamespace, ingressName, timeStr, uniqueSuffix),
+			Name:      logName(ingressNamespace+"-"+ingressName, timeStr+"-"+uniqueSuffix),
 			Namespace: ingressNamespace,
 		},
 		Spec: ingressauditv1alpha1.IngressTLSLogSpec{
-			LogLevel:            ErrLogLevel,
+			LogLevel:            level,
 			NameSpace:           ingressNamespace,
 			IngressName:         ingressName,
 			Message:             err.Error(),
+			Reason:              ReasonFor(err).Code,
+			Host:                detail.host,
 			GenerationTimestamp: &metav1.Time{Time: updateTime},
 		},
 	}
+	TLSLog.Labels = logLabels(TLSLog.Spec)
 
 	if err := ctrl.SetControllerReference(ingress, TLSLog, r.Scheme); err != nil {
 		return nil, err
@@ -232,26 +415,61 @@ func (r *IngressTLSLogReconciler) createTLSLog(ingress *networkingv1.Ingress, in
 }
 
 // logErrorAndUpdateMaps creates ingresstlslogs instance and updates maps of IngressUpdateTimeMap and IngressErrorMap
-func (r *IngressTLSLogReconciler) logErrorAndUpdateMaps(ctx context.Context, ingress *networkingv1.Ingress, ingressNs, ingressName string, errType error, ingressNamespacedName string) error {
+func (r *IngressTLSLogReconciler) logErrorAndUpdateMaps(ctx context.Context, ingress *networkingv1.Ingress, ingressNs, ingressName string, detail findingDetail, errType error, ingressNamespacedName string) error {
 	updateTime := time.Now()
-	TLSlog, err := r.createTLSLog(ingress, ingressNs, ingressName, errType, updateTime)
+	finding := r.nextFinding(ingressNamespacedName, errType, detail.severity, updateTime)
+	finding.Host = detail.host
+	TLSlog, err := r.createTLSLog(ingress, ingressNs, ingressName, detail, finding.Severity, errType, updateTime)
 	if err != nil {
 		return fmt.Errorf("failed to create TLS log: %v", err)
 	}
-	if err = r.Create(ctx, TLSlog); err != nil {
+	switch {
+	case r.DryRun:
+		logDryRun(logf.FromContext(ctx), TLSlog)
+	case r.LogMode == LogModeUpsert:
+		err = r.upsertTLSLog(ctx, TLSlog)
+	default:
+		err = r.Create(ctx, TLSlog)
+	}
+	if err != nil {
 		return err
 	}
 
 	r.updateValueForKey(ingressNamespacedName, errType, updateTime)
+	if r.IngressFindingMap != nil {
+		r.IngressFindingMap.Set(ingressNamespacedName, finding)
+	}
+
+	if r.Notifier != nil && !r.DryRun {
+		r.Notifier.Notify(newEvent(notify.EventCreated, TLSlog, errType, detail))
+	}
 
 	return nil
 }
 
+// newEvent creates the notification event of the ingresstlslogs instance
+func newEvent(eventType string, TLSLog *ingressauditv1alpha1.IngressTLSLog, errType error, detail findingDetail) notify.Event {
+	return notify.Event{
+		Type:        eventType,
+		Timestamp:   TLSLog.Spec.GenerationTimestamp.Time,
+		Namespace:   TLSLog.Spec.NameSpace,
+		Ingress:     TLSLog.Spec.IngressName,
+		Host:        TLSLog.Spec.Host,
+		Reason:      TLSLog.Spec.Reason,
+		Category:    ReasonFor(errType).Category,
+		Severity:    TLSLog.Spec.LogLevel,
+		Message:     TLSLog.Spec.Message,
+		LogName:     TLSLog.Name,
+		Fingerprint: detail.fingerprint,
+	}
+}
+
 // handleIngressError handles different types of err when checking the TLS status of ingress
 func (r *IngressTLSLogReconciler) handleIngressError(
 	ctx context.Context,
 	ingress *networkingv1.Ingress,
 	ingressNs, ingressName, ingressNamespacedName string,
+	detail findingDetail,
 	err error,
 	errType error,
 	log logr.Logger,
@@ -263,7 +481,7 @@ func (r *IngressTLSLogReconciler) handleIngressError(
 	}
 
 	// Otherwise, log the error and update the internal maps
-	if updateErr := r.logErrorAndUpdateMaps(ctx, ingress, ingressNs, ingressName, errType, ingressNamespacedName); updateErr != nil {
+	if updateErr := r.logErrorAndUpdateMaps(ctx, ingress, ingressNs, ingressName, detail, errType, ingressNamespacedName); updateErr != nil {
 		log.Error(err, ErrCreateTLSLog.Error())
 		return ctrl.Result{}, ErrCreateTLSLog
 	}
@@ -280,9 +498,52 @@ func (r *IngressTLSLogReconciler) handleIngressError(
 // SetupWithManager sets up the controller with the Manager.
 // Monitors the ingress
 func (r *IngressTLSLogReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&networkingv1.Ingress{}).
+	// The escalation of a finding depends on its recorded age and repetitions
+	if r.Escalation != nil && r.IngressFindingMap == nil {
+		return errors.New("IngressFindingMap is required by Escalation")
+	}
+
+	// Remove the store entries of ingresses whose deletion was missed, e.g. during a restart of the watch
+	if err := mgr.Add(manager.RunnableFunc(r.sweepStores)); err != nil {
+		return err
+	}
+
+	// The open findings are exported when the metrics are scraped
+	openFindings.add(r)
+
+	if r.DryRun {
+		if err := mgr.Add(manager.RunnableFunc(r.logDryRunSummary)); err != nil {
+			return err
+		}
+	}
+
+	// The cert-manager resources are read from the cache, the CertificateRequests by the name of their Certificate
+	if r.CertManagerReader == nil {
+		installed, err := certmanager.Installed(mgr.GetRESTMapper())
+		if err != nil {
+			return err
+		}
+		if installed {
+			if err := certmanager.IndexCertificateRequests(context.Background(), mgr.GetFieldIndexer()); err != nil {
+				return err
+			}
+			r.CertManagerReader = mgr.GetCache()
+		}
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&networkingv1.Ingress{}, builder.WithPredicates(ignoreAuditAnnotationUpdates())).
 		Named("ingresstlslog").
 		Owns(&ingressauditv1alpha1.IngressTLSLog{}).
-		Complete(r)
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles})
+
+	// Only the metadata of the secrets is cached, a change of a secret reconciles the ingresses referencing it
+	if r.MetadataOnlySecrets {
+		if err := r.indexTLSSecrets(mgr); err != nil {
+			return err
+		}
+		b = b.WatchesMetadata(&v1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.ingressesForSecret))
+	}
+
+	return b.Complete(r)
 }
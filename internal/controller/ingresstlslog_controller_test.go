@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"github.com/MMMMMMorty/ingress-auditor/internal/store"
 	. "github.com/onsi/ginkgo/v2"
@@ -152,10 +153,10 @@ var _ = Describe("IngressTLSLog Controller", func() {
 			By("Cleanup the specific resource instance ingress")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 		})
-		It("should be failed to reconcile the resource", func() {
-			By("Reconciling the ingress with creating TLS log failure")
+		It("should forget the errors of a deleted ingress and reconcile without error", func() {
+			By("Reconciling a deleted ingress")
 
-			typeIngressFailure := types.NamespacedName{
+			typeIngressDeleted := types.NamespacedName{
 				Name:      resourceNameFailure,
 				Namespace: "test-ingress-failure",
 			}
@@ -167,12 +168,19 @@ var _ = Describe("IngressTLSLog Controller", func() {
 				IngressErrorMap:      store.NewIngressErrorMap(),
 				IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
 			}
+			controllerReconciler.IngressErrorMap.Set(typeIngressDeleted.String(), ErrHTTPRedirectMissing)
+			controllerReconciler.IngressUpdateTimeMap.Set(typeIngressDeleted.String(), time.Now())
 
-			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeIngressFailure,
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeIngressDeleted,
 			})
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("failed to create new TLS log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			_, exist := controllerReconciler.IngressErrorMap.Get(typeIngressDeleted.String())
+			Expect(exist).To(BeFalse())
+			_, exist = controllerReconciler.IngressUpdateTimeMap.Get(typeIngressDeleted.String())
+			Expect(exist).To(BeFalse())
 
 			// namespace where the project is deployed in
 			const namespace = "ingress-auditor-system"
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+)
+
+// Labels of the ingresstlslogs instances, so they can be selected without reading their spec
+const (
+	IngressLabel   = "ingress-audit.morty.dev/ingress"
+	NamespaceLabel = "ingress-audit.morty.dev/namespace"
+	ReasonLabel    = "ingress-audit.morty.dev/reason"
+	SeverityLabel  = "ingress-audit.morty.dev/severity"
+	// HostHashLabel is set for logs about a host, as hosts may be longer than a label value
+	HostHashLabel = "ingress-audit.morty.dev/host-hash"
+)
+
+// HostHash returns the value of the HostHashLabel of the host
+func HostHash(host string) string {
+	sum := sha256.Sum256([]byte(host))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// IngressLabelValue returns the value of the IngressLabel of the ingress name.
+// Names longer than a label value are truncated and suffixed with the first 10 hex digits of their SHA-256.
+func IngressLabelValue(name string) string {
+	if len(name) <= validation.LabelValueMaxLength {
+		return name
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(sum[:])[:10]
+	// A label value must end with an alphanumeric character
+	prefix := strings.TrimRight(name[:validation.LabelValueMaxLength-len(suffix)-1], ".-")
+	return prefix + "-" + suffix
+}
+
+// logLabels returns the labels of the ingresstlslogs instance with the spec
+func logLabels(spec ingressauditv1alpha1.IngressTLSLogSpec) map[string]string {
+	labels := map[string]string{
+		IngressLabel:   IngressLabelValue(spec.IngressName),
+		NamespaceLabel: spec.NameSpace,
+		ReasonLabel:    spec.Reason,
+		SeverityLabel:  spec.LogLevel,
+	}
+	if spec.Host != "" {
+		labels[HostHashLabel] = HostHash(spec.Host)
+	}
+
+	return labels
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+)
+
+var _ = Describe("Log labels", func() {
+	It("should label the log with its ingress, reason, severity and host", func() {
+		labels := logLabels(ingressauditv1alpha1.IngressTLSLogSpec{
+			LogLevel:    ErrLogLevel,
+			NameSpace:   "ns",
+			IngressName: "web",
+			Reason:      "TLSVerification",
+			Host:        "a-very-long-host-name-that-does-not-fit-into-a-label-value.sub.example.com",
+		})
+
+		Expect(labels).To(HaveKeyWithValue(IngressLabel, "web"))
+		Expect(labels).To(HaveKeyWithValue(NamespaceLabel, "ns"))
+		Expect(labels).To(HaveKeyWithValue(ReasonLabel, "TLSVerification"))
+		Expect(labels).To(HaveKeyWithValue(SeverityLabel, ErrLogLevel))
+		for _, value := range labels {
+			Expect(validation.IsValidLabelValue(value)).To(BeEmpty())
+		}
+	})
+
+	It("should truncate and hash ingress names longer than a label value", func() {
+		name := strings.Repeat("w", 61) + "." + strings.Repeat("w", 100)
+		labels := logLabels(ingressauditv1alpha1.IngressTLSLogSpec{NameSpace: "ns", IngressName: name})
+
+		value := labels[IngressLabel]
+		Expect(validation.IsValidLabelValue(value)).To(BeEmpty())
+		Expect(value).To(Equal(IngressLabelValue(name)))
+		Expect(value).To(HavePrefix(strings.Repeat("w", 52) + "-"))
+		Expect(value).NotTo(Equal(IngressLabelValue(name + "w")))
+		Expect(IngressLabelValue("web")).To(Equal("web"))
+	})
+
+	It("should not set the host hash for errors about the whole ingress", func() {
+		labels := logLabels(ingressauditv1alpha1.IngressTLSLogSpec{NameSpace: "ns", IngressName: "web"})
+		Expect(labels).NotTo(HaveKey(HostHashLabel))
+	})
+})
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// OpenFinding is the error currently recorded for an ingress
+type OpenFinding struct {
+	Finding
+	// FirstSeen is when the error was logged first
+	FirstSeen time.Time `json:"firstSeen"`
+	// LastSeen is when the error was logged last
+	LastSeen time.Time `json:"lastSeen"`
+	// Repeats counts how often the error was logged again since FirstSeen
+	Repeats int `json:"repeats"`
+}
+
+// OpenFindings returns the errors currently recorded for the ingresses, sorted by namespace and name
+func (r *IngressTLSLogReconciler) OpenFindings() []OpenFinding {
+	errs := r.IngressErrorMap.List()
+	findings := make([]OpenFinding, 0, len(errs))
+
+	for key, errType := range errs {
+		namespace, name, _ := strings.Cut(key, "/")
+		reason := ReasonFor(errType)
+		lastSeen, _ := r.IngressUpdateTimeMap.Get(key)
+
+		finding := OpenFinding{
+			Finding: Finding{
+				Namespace: namespace,
+				Ingress:   name,
+				Reason:    reason.Code,
+				Category:  reason.Category,
+				Severity:  ErrLogLevel,
+				Message:   errType.Error(),
+			},
+			FirstSeen: lastSeen,
+			LastSeen:  lastSeen,
+		}
+
+		if r.IngressFindingMap != nil {
+			if recorded, ok := r.IngressFindingMap.Get(key); ok {
+				finding.Host = recorded.Host
+				finding.Severity = recorded.Severity
+				finding.FirstSeen = recorded.FirstSeen
+				finding.Repeats = recorded.Repeats
+			}
+		}
+
+		findings = append(findings, finding)
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Namespace != findings[j].Namespace {
+			return findings[i].Namespace < findings[j].Namespace
+		}
+		return findings[i].Ingress < findings[j].Ingress
+	})
+
+	return findings
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+// ProbeConfig configures which address the TLS probe dials for an ingress host.
+// The rule host is always sent as SNI, only the dialed address changes.
+type ProbeConfig struct {
+	// Port is the port dialed, 443 is used when it is zero
+	Port int
+
+	// Address is an explicit address (e.g. the ingress controller Service) dialed for every host.
+	// It takes precedence over UseLoadBalancerStatus
+	Address string
+
+	// UseLoadBalancerStatus dials the first IP or hostname of the ingress status.loadBalancer.
+	// If the status is empty, the host itself is dialed
+	UseLoadBalancerStatus bool
+}
+
+// targetFor resolves the probe target of the host declared in the ingress
+func (c ProbeConfig) targetFor(ingress *networkingv1.Ingress, host string) utils.ProbeTarget {
+	target := utils.ProbeTarget{
+		Host: host,
+		Port: c.Port,
+	}
+
+	if c.Address != "" {
+		target.Address = c.Address
+		return target
+	}
+
+	if c.UseLoadBalancerStatus {
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			if lb.IP != "" {
+				target.Address = lb.IP
+				break
+			}
+			if lb.Hostname != "" {
+				target.Address = lb.Hostname
+				break
+			}
+		}
+	}
+
+	return target
+}
+
+// ParseProbeAddress splits an explicit probe address into its host and port, the port is zero if it has none.
+// An IPv6 address with a port must be bracketed, e.g. [fd00::1]:8443.
+func ParseProbeAddress(address string) (string, int, error) {
+	if !strings.Contains(address, ":") || net.ParseIP(address) != nil {
+		return address, 0, nil
+	}
+	if strings.HasPrefix(address, "[") && strings.HasSuffix(address, "]") {
+		return strings.TrimSuffix(strings.TrimPrefix(address, "["), "]"), 0, nil
+	}
+
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid probe address %q: %w", address, err)
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil || port < 1 || port > 65535 || host == "" {
+		return "", 0, fmt.Errorf("invalid probe address %q, expected host or host:port", address)
+	}
+
+	return host, port, nil
+}
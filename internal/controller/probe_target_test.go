@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+var _ = Describe("Probe target", func() {
+	ingress := &networkingv1.Ingress{}
+	ingress.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{Hostname: "lb.example.com"}, {IP: "10.0.0.2"}}
+
+	DescribeTable("should dial the configured address and keep the host as SNI",
+		func(config ProbeConfig, lb bool, expected string) {
+			target := config.targetFor(&networkingv1.Ingress{}, "foo.com")
+			if lb {
+				target = config.targetFor(ingress, "foo.com")
+			}
+			Expect(target.Host).To(Equal("foo.com"))
+			Expect(target.DialAddress()).To(Equal(expected))
+		},
+		Entry("the host on 443 in default", ProbeConfig{}, false, "foo.com:443"),
+		Entry("the host on the port", ProbeConfig{Port: 8443}, false, "foo.com:8443"),
+		Entry("the explicit address", ProbeConfig{Address: "ingress-nginx.svc", Port: 443}, true, "ingress-nginx.svc:443"),
+		Entry("a bracketed IPv6 address", ProbeConfig{Address: "fd00::1"}, false, "[fd00::1]:443"),
+		Entry("the first load balancer", ProbeConfig{UseLoadBalancerStatus: true}, true, "lb.example.com:443"),
+		Entry("the host without load balancer status", ProbeConfig{UseLoadBalancerStatus: true}, false, "foo.com:443"),
+	)
+
+	DescribeTable("should split the port of the probe address",
+		func(address, host string, port int) {
+			h, p, err := ParseProbeAddress(address)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h).To(Equal(host))
+			Expect(p).To(Equal(port))
+		},
+		Entry("a hostname", "ingress-nginx.svc", "ingress-nginx.svc", 0),
+		Entry("a hostname with port", "ingress-nginx.svc:8443", "ingress-nginx.svc", 8443),
+		Entry("an IPv4 address with port", "10.0.0.1:443", "10.0.0.1", 443),
+		Entry("an IPv6 address", "fd00::1", "fd00::1", 0),
+		Entry("a bracketed IPv6 address", "[fd00::1]", "fd00::1", 0),
+		Entry("a bracketed IPv6 address with port", "[fd00::1]:8443", "fd00::1", 8443),
+	)
+
+	DescribeTable("should reject invalid probe addresses",
+		func(address string) {
+			_, _, err := ParseProbeAddress(address)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("a port out of range", "ingress-nginx.svc:70000"),
+		Entry("a named port", "ingress-nginx.svc:https"),
+		Entry("a missing host", ":8443"),
+		Entry("two ports", "ingress-nginx.svc:443:443"),
+	)
+})
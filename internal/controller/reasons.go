@@ -0,0 +1,270 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Categories of the reasons, telling who should act on the log
+const (
+	// CategoryConfiguration means the ingress or its secret is misconfigured
+	CategoryConfiguration = "Configuration"
+	// CategoryNetwork means the host could not be reached, e.g. DNS or firewall problems
+	CategoryNetwork = "Network"
+	// CategoryCertificate means the host served an invalid certificate
+	CategoryCertificate = "Certificate"
+	// CategoryInternal means the auditor itself failed
+	CategoryInternal = "Internal"
+)
+
+// Reason describes the machine readable code of an error type
+type Reason struct {
+	// Code is recorded in the reason of the IngressTLSLog
+	Code string
+	// Category tells who should act on the error
+	Category string
+	// Description explains the error and how to fix it
+	Description string
+	// Severity is the severity the reason is usually reported with, Error if it is empty
+	Severity string
+}
+
+// DefaultSeverity returns the severity the reason is usually reported with
+func (r Reason) DefaultSeverity() string {
+	if r.Severity == "" {
+		return ErrLogLevel
+	}
+
+	return r.Severity
+}
+
+// reasonsMu guards reasons, custom reasons are registered while reconcilers and the API read them
+var reasonsMu sync.RWMutex
+
+// reasons maps each error type to its reason
+var reasons = map[error]Reason{
+	ErrFetchIngress: {
+		Code:        "FetchIngress",
+		Category:    CategoryInternal,
+		Description: "The ingress could not be read from the API server.",
+	},
+	ErrSecretNameMissing: {
+		Code:        "SecretNameMissing",
+		Category:    CategoryConfiguration,
+		Description: "A TLS block of the ingress does not define secretName.",
+	},
+	ErrFetchSecret: {
+		Code:        "FetchSecret",
+		Category:    CategoryConfiguration,
+		Description: "The secret referenced by the ingress TLS block does not exist or could not be read.",
+	},
+	ErrCrtOrKeyMissing: {
+		Code:        "CrtOrKeyMissing",
+		Category:    CategoryConfiguration,
+		Description: "The referenced secret is not of type kubernetes.io/tls or lacks tls.crt or tls.key.",
+	},
+	ErrHostsMissing: {
+		Code:        "HostsMissing",
+		Category:    CategoryConfiguration,
+		Description: "A TLS block of the ingress does not define hosts.",
+	},
+	ErrTLSVerification: {
+		Code:        "TLSVerification",
+		Category:    CategoryCertificate,
+		Description: "The TLS handshake with the host failed for an unclassified reason.",
+	},
+	ErrHTTPRedirectMissing: {
+		Code:        "HTTPRedirectMissing",
+		Category:    CategoryConfiguration,
+		Description: "The ingress does not use TLS and does not redirect HTTP traffic either.",
+	},
+	ErrCreateTLSLog: {
+		Code:        "CreateTLSLog",
+		Category:    CategoryInternal,
+		Description: "The auditor failed to create the IngressTLSLog.",
+	},
+	ErrCertificateIssuancePending: {
+		Code:        "CertificateIssuancePending",
+		Category:    CategoryCertificate,
+		Description: "cert-manager has not issued the secret yet, the ingress serves no valid certificate until it does.",
+	},
+	ErrCertificateIssuanceFailed: {
+		Code:        "CertificateIssuanceFailed",
+		Category:    CategoryCertificate,
+		Description: "cert-manager failed to issue the secret, check the Certificate and CertificateRequest events.",
+	},
+	ErrCertificateNotReady: {
+		Code:        "CertificateNotReady",
+		Category:    CategoryCertificate,
+		Description: "The cert-manager Certificate behind the secret is not Ready.",
+	},
+	ErrCertificateRenewalFailing: {
+		Code:        "CertificateRenewalFailing",
+		Category:    CategoryCertificate,
+		Description: "cert-manager failed to renew the certificate, the secret keeps the old certificate until it expires.",
+	},
+	ErrCertificateSANMismatch: {
+		Code:        "CertificateSANMismatch",
+		Category:    CategoryCertificate,
+		Description: "The subject alternative names of the certificate in the secret do not cover the TLS host.",
+	},
+	ErrCertificateExpiringSoon: {
+		Code:        "CertificateExpiringSoon",
+		Category:    CategoryCertificate,
+		Description: "The certificate in the secret expires within the warning period, renew it before the TLS verification fails.",
+		Severity:    WarnLogLevel,
+	},
+	ErrRuleHostNotCovered: {
+		Code:        "RuleHostNotCovered",
+		Category:    CategoryConfiguration,
+		Description: "A rule host is not listed in any TLS block, so it is served over plain HTTP.",
+	},
+	ErrTLSHostWithoutRule: {
+		Code:        "TLSHostWithoutRule",
+		Category:    CategoryConfiguration,
+		Description: "A TLS host is not routed by any rule, which usually is a typo in the hosts.",
+	},
+	ErrDefaultBackendOnly: {
+		Code:        "DefaultBackendOnly",
+		Category:    CategoryConfiguration,
+		Description: "The ingress only defines a default backend, so its TLS hosts cannot be verified against any rule.",
+	},
+	ErrDNSResolution: {
+		Code:        "DNSResolution",
+		Category:    CategoryNetwork,
+		Description: "The host, or the configured probe address, does not resolve in the cluster DNS.",
+	},
+	ErrConnectionRefused: {
+		Code:        "ConnectionRefused",
+		Category:    CategoryNetwork,
+		Description: "Nothing listens on the probed address and port.",
+	},
+	ErrConnectionTimeout: {
+		Code:        "ConnectionTimeout",
+		Category:    CategoryNetwork,
+		Description: "The connection to the host timed out, often because of a firewall or network policy.",
+	},
+	ErrNetworkUnreachable: {
+		Code:        "NetworkUnreachable",
+		Category:    CategoryNetwork,
+		Description: "There is no route to the probed address.",
+	},
+	ErrTLSHandshake: {
+		Code:        "TLSHandshake",
+		Category:    CategoryCertificate,
+		Description: "The host accepted the connection but the TLS handshake failed, e.g. no certificate for the SNI.",
+	},
+	ErrHostnameMismatch: {
+		Code:        "HostnameMismatch",
+		Category:    CategoryCertificate,
+		Description: "The served certificate does not list the host in its subject alternative names.",
+	},
+	ErrCertificateExpired: {
+		Code:        "CertificateExpired",
+		Category:    CategoryCertificate,
+		Description: "The served certificate is past its notAfter date.",
+	},
+	ErrCertificateNotYetValid: {
+		Code:        "CertificateNotYetValid",
+		Category:    CategoryCertificate,
+		Description: "The served certificate is before its notBefore date.",
+	},
+	ErrUnknownAuthority: {
+		Code:        "UnknownAuthority",
+		Category:    CategoryCertificate,
+		Description: "The served certificate is not the one in the secret, e.g. the controller serves its default certificate.",
+	},
+}
+
+// ReasonFor returns the reason of the error type
+func ReasonFor(errType error) Reason {
+	reasonsMu.RLock()
+	defer reasonsMu.RUnlock()
+
+	if reason, ok := reasons[errType]; ok {
+		return reason
+	}
+
+	return Reason{Code: "Unknown", Category: CategoryInternal, Description: errType.Error()}
+}
+
+// RegisterReason adds the reason of the error type of a custom checker to the catalog.
+// The code must not be used by another reason. It is safe to call while the reasons are read.
+func RegisterReason(errType error, reason Reason) error {
+	reasonsMu.Lock()
+	defer reasonsMu.Unlock()
+
+	if _, exist := reasonByCode(reason.Code); exist {
+		return fmt.Errorf("reason %s already exists", reason.Code)
+	}
+
+	reasons[errType] = reason
+	return nil
+}
+
+// errTypeByCode returns the error type of the reason with the code, the boolean is false if it does not exist
+func errTypeByCode(code string) (error, bool) {
+	reasonsMu.RLock()
+	defer reasonsMu.RUnlock()
+
+	for errType, reason := range reasons {
+		if reason.Code == code {
+			return errType, true
+		}
+	}
+
+	return nil, false
+}
+
+// ReasonByCode returns the reason with the code, the boolean is false if it does not exist
+func ReasonByCode(code string) (Reason, bool) {
+	reasonsMu.RLock()
+	defer reasonsMu.RUnlock()
+
+	return reasonByCode(code)
+}
+
+// reasonByCode is ReasonByCode with reasonsMu held
+func reasonByCode(code string) (Reason, bool) {
+	for _, reason := range reasons {
+		if strings.EqualFold(reason.Code, code) {
+			return reason, true
+		}
+	}
+
+	return Reason{}, false
+}
+
+// Reasons returns all reasons sorted by their code
+func Reasons() []Reason {
+	reasonsMu.RLock()
+	all := make([]Reason, 0, len(reasons))
+	for _, reason := range reasons {
+		all = append(all, reason)
+	}
+	reasonsMu.RUnlock()
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Code < all[j].Code
+	})
+
+	return all
+}
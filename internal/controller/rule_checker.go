@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+// NewRuleChecker creates the checker of a custom rule of the policy and registers its reason.
+// An expression failing to evaluate, e.g. on a missing field, fails the rule. The rules are registered
+// after the built-in checkers, so they only run on ingresses passing all built-in checks.
+func NewRuleChecker(rule *policy.Rule) (Checker, error) {
+	errType := errors.New(rule.Message)
+	if err := RegisterReason(errType, ruleReason(rule)); err != nil {
+		return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+	}
+
+	return newRuleChecker(rule, errType), nil
+}
+
+// NewPolicyCheckerRegistry creates a registry of the built-in checkers followed by the checkers of the rules,
+// with the named checkers disabled
+func NewPolicyCheckerRegistry(rules []policy.Rule, disabled []string) (*CheckerRegistry, error) {
+	registry := NewBuiltinCheckerRegistry()
+	for i := range rules {
+		checker, err := NewRuleChecker(&rules[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Register(checker); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range disabled {
+		if err := registry.SetEnabled(name, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// ruleReason returns the reason of the findings of the rule
+func ruleReason(rule *policy.Rule) Reason {
+	return Reason{
+		Code:        rule.Reason,
+		Category:    CategoryConfiguration,
+		Description: fmt.Sprintf("The ingress violates the custom rule %s: %s", rule.Name, rule.Expression),
+		Severity:    rule.Severity,
+	}
+}
+
+// newRuleChecker creates the checker of the rule reporting the error type, which is not registered
+func newRuleChecker(rule *policy.Rule, errType error) Checker {
+	reason := ruleReason(rule)
+	return NewChecker(rule.Name, []error{errType}, func(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+		vars, err := deps.ruleVars(ctx, ingress)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "unable to build the variables of the rules")
+			return nil
+		}
+
+		passed, err := rule.Eval(ctx, vars)
+		if passed {
+			return nil
+		}
+
+		message := rule.Message
+		if err != nil {
+			message = fmt.Sprintf("%s: %v", rule.Message, err)
+		}
+		return []Finding{{
+			Namespace: ingress.Namespace,
+			Ingress:   ingress.Name,
+			Reason:    reason.Code,
+			Category:  reason.Category,
+			Severity:  rule.Severity,
+			Message:   message,
+		}}
+	})
+}
+
+// ruleVars returns the variables of the rule expressions for the ingress, they are built once per reconcile
+func (d *CheckDeps) ruleVars(ctx context.Context, ingress *networkingv1.Ingress) (map[string]any, error) {
+	if d.vars != nil {
+		return d.vars, nil
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ingress)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := []map[string]any{}
+	certificates := []map[string]any{}
+	seen := map[types.NamespacedName]bool{}
+	for _, tlsInstance := range ingress.Spec.TLS {
+		key, secret, ok, err := d.Secret(ctx, ingress, tlsInstance.SecretName)
+		if !ok || err != nil || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		secrets = append(secrets, secretVars(secret))
+
+		cert, err := utils.ParseCertificate(secret.Data[v1.TLSCertKey])
+		if err != nil {
+			continue
+		}
+		certificates = append(certificates, map[string]any{
+			"secretName":       secret.Name,
+			"subject":          cert.Subject.String(),
+			"issuer":           cert.Issuer.String(),
+			"issuerCommonName": cert.Issuer.CommonName,
+			"dnsNames":         cert.DNSNames,
+			"serialNumber":     cert.SerialNumber.String(),
+			"notBefore":        cert.NotBefore,
+			"notAfter":         cert.NotAfter,
+			"fingerprint":      utils.Fingerprint(secret.Data[v1.TLSCertKey]),
+		})
+	}
+
+	now := d.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	d.vars = map[string]any{
+		policy.IngressVariable:      object,
+		policy.SecretsVariable:      secrets,
+		policy.CertificatesVariable: certificates,
+		policy.NowVariable:          now,
+	}
+	return d.vars, nil
+}
+
+// secretVars returns the metadata of the secret available to the rule expressions, its data is left out
+func secretVars(secret *v1.Secret) map[string]any {
+	labels := secret.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	annotations := secret.Annotations
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	return map[string]any{
+		"name":        secret.Name,
+		"namespace":   secret.Namespace,
+		"type":        string(secret.Type),
+		"labels":      labels,
+		"annotations": annotations,
+	}
+}
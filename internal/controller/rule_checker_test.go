@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+)
+
+var _ = Describe("Rule checker", func() {
+	ctx := context.Background()
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod-shop", Name: "web"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{SecretName: "tls", Hosts: []string{"foo.com"}}},
+		},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "prod-shop",
+			Name:        "tls",
+			Annotations: map[string]string{"cert-manager.io/issuer-name": "internal-ca"},
+		},
+		Type: v1.SecretTypeTLS,
+	}
+
+	newDeps := func() *CheckDeps {
+		return &CheckDeps{
+			Client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build(),
+			SecretKey: func(ingressNamespace, secretName string) (types.NamespacedName, bool, error) {
+				return resolveSecretKey(ingressNamespace, secretName, false, nil)
+			},
+		}
+	}
+
+	It("should report the ingresses failing the rule with its reason and severity", func() {
+		p, err := policy.Parse([]byte(`
+rules:
+- name: prod-issuer
+  expression: >-
+    !ingress.metadata.namespace.startsWith("prod-") ||
+    secrets.all(s, s.annotations["cert-manager.io/issuer-name"] == "letsencrypt")
+  reason: ProdIssuerRuleTest
+  severity: Warn
+  message: ingresses in prod-* namespaces must use certificates of letsencrypt
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		checker, err := NewRuleChecker(&p.Rules[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checker.Name()).To(Equal("prod-issuer"))
+		Expect(checker.Reasons()).To(ConsistOf("ProdIssuerRuleTest"))
+
+		findings := checker.Check(ctx, ingress, newDeps())
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Reason).To(Equal("ProdIssuerRuleTest"))
+		Expect(findings[0].Severity).To(Equal(WarnLogLevel))
+		Expect(findings[0].Message).To(Equal("ingresses in prod-* namespaces must use certificates of letsencrypt"))
+
+		errType, ok := errTypeByCode("ProdIssuerRuleTest")
+		Expect(ok).To(BeTrue())
+		Expect(errType.Error()).To(Equal(findings[0].Message))
+	})
+
+	It("should fail the rule if the expression cannot be evaluated", func() {
+		p, err := policy.Parse([]byte(`
+rules:
+- name: class
+  expression: ingress.spec.ingressClassName == "external"
+  reason: ClassRuleTest
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		checker, err := NewRuleChecker(&p.Rules[0])
+		Expect(err).NotTo(HaveOccurred())
+
+		findings := checker.Check(ctx, ingress, newDeps())
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Message).To(ContainSubstring("no such key"))
+	})
+
+	It("should not register a reason twice", func() {
+		p, err := policy.Parse([]byte("rules:\n- name: redirect\n  expression: 'true'\n  reason: HTTPRedirectMissing\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = NewRuleChecker(&p.Rules[0])
+		Expect(err).To(MatchError(ContainSubstring("already exists")))
+	})
+
+	It("should only report the rules once the built-in checkers pass", func() {
+		p, err := policy.Parse([]byte("rules:\n- name: always-failing\n  expression: 'false'\n  reason: HiddenRuleTest\n"))
+		Expect(err).NotTo(HaveOccurred())
+		checker, err := NewRuleChecker(&p.Rules[0])
+		Expect(err).NotTo(HaveOccurred())
+
+		registry := NewBuiltinCheckerRegistry()
+		Expect(registry.Register(checker)).To(Succeed())
+
+		valid := secret.DeepCopy()
+		valid.Data = map[string][]byte{
+			v1.TLSCertKey:       newTestCertificate(time.Now().Add(time.Hour), "foo.com"),
+			v1.TLSPrivateKeyKey: []byte("key"),
+		}
+		newDeps := func(objs ...client.Object) *CheckDeps {
+			return &CheckDeps{
+				Client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objs...).Build(),
+				SecretKey: func(ingressNamespace, secretName string) (types.NamespacedName, bool, error) {
+					return resolveSecretKey(ingressNamespace, secretName, false, nil)
+				},
+				CheckTLS: func(context.Context, *networkingv1.Ingress, string, []byte, []byte) error { return nil },
+			}
+		}
+
+		// The missing secret is reported by the built-in checkers, the rule does not run
+		findings := registry.Run(ctx, ingress, newDeps())
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Reason).To(Equal(ReasonFor(ErrFetchSecret).Code))
+
+		findings = registry.Run(ctx, ingress, newDeps(valid))
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Reason).To(Equal("HiddenRuleTest"))
+	})
+
+	It("should register reasons while they are read", func() {
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(2)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(RegisterReason(fmt.Errorf("concurrent %d", i), Reason{Code: fmt.Sprintf("ConcurrentRuleTest%d", i)})).To(Succeed())
+			}()
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, _ = errTypeByCode("HTTPRedirectMissing")
+				Expect(Reasons()).NotTo(BeEmpty())
+			}()
+		}
+		wg.Wait()
+
+		_, ok := ReasonByCode("ConcurrentRuleTest9")
+		Expect(ok).To(BeTrue())
+	})
+})
@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// tlsSecretIndex indexes the ingresses by the namespace/name of the secrets of their TLS blocks
+const tlsSecretIndex = ".spec.tls.secretName"
+
+// metadataSecretReader reads the secrets from the API server if their metadata is in the cache,
+// so only the secrets referenced by the ingresses are read in full and none of them is cached
+type metadataSecretReader struct {
+	// Reader reads all other objects
+	client.Reader
+	// metadataReader reads the metadata of the secrets from the cache
+	metadataReader client.Reader
+	// apiReader reads the secrets from the API server
+	apiReader client.Reader
+	// certificates keeps the secrets read from the API server without their tls.key, nil keeps none
+	certificates *sync.Map
+	// certificateOnly serves the kept secret if its resourceVersion is unchanged, the tls.key of the secrets is dropped
+	certificateOnly bool
+}
+
+// Get reads a secret from the API server if its metadata is cached, other objects from the reader
+func (s *metadataSecretReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	secret, ok := obj.(*v1.Secret)
+	if !ok {
+		return s.Reader.Get(ctx, key, obj, opts...)
+	}
+
+	// A missing secret is reported by the cache without a request to the API server
+	metadata := &metav1.PartialObjectMetadata{}
+	metadata.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("Secret"))
+	if err := s.metadataReader.Get(ctx, key, metadata); err != nil {
+		if apierrors.IsNotFound(err) && s.certificates != nil {
+			s.certificates.Delete(key)
+		}
+		return err
+	}
+
+	if s.certificateOnly && s.certificates != nil {
+		if kept, ok := s.certificates.Load(key); ok && kept.(*v1.Secret).ResourceVersion == metadata.ResourceVersion {
+			kept.(*v1.Secret).DeepCopyInto(secret)
+			return nil
+		}
+	}
+
+	if err := s.apiReader.Get(ctx, key, secret, opts...); err != nil {
+		return err
+	}
+
+	if s.certificateOnly {
+		delete(secret.Data, v1.TLSPrivateKeyKey)
+	}
+	if s.certificates != nil {
+		kept := secret.DeepCopy()
+		delete(kept.Data, v1.TLSPrivateKeyKey)
+		s.certificates.Store(key, kept)
+	}
+	return nil
+}
+
+// secretReader returns the reader of the secrets and cert-manager resources of the checkers
+func (r *IngressTLSLogReconciler) secretReader() client.Reader {
+	if !r.MetadataOnlySecrets || r.APIReader == nil || r.SecretMetadataReader == nil {
+		return r
+	}
+
+	return &metadataSecretReader{
+		Reader:         r,
+		metadataReader: r.SecretMetadataReader,
+		apiReader:      r.APIReader,
+		certificates:   &r.certificates,
+	}
+}
+
+// certificateReader returns the reader of the secrets without their tls.key, for the rules and the certificates of the API.
+// With MetadataOnlySecrets a secret is only read from the API server if it changed since it was last read.
+func (r *IngressTLSLogReconciler) certificateReader() client.Reader {
+	reader, ok := r.secretReader().(*metadataSecretReader)
+	if !ok {
+		return r
+	}
+
+	reader.certificateOnly = true
+	return reader
+}
+
+// Certificate returns the tls.crt of the secret read like the rules do, so with MetadataOnlySecrets only a secret
+// in the metadata cache is read from the API server, once per change. The boolean is false if it cannot be read.
+func (r *IngressTLSLogReconciler) Certificate(ctx context.Context, key types.NamespacedName) ([]byte, bool) {
+	secret := &v1.Secret{}
+	if err := r.certificateReader().Get(ctx, key, secret); err != nil {
+		return nil, false
+	}
+
+	crt, ok := secret.Data[v1.TLSCertKey]
+	return crt, ok
+}
+
+// tlsSecretKeys returns the namespace/name of the secrets referenced by the TLS blocks of the ingress
+func (r *IngressTLSLogReconciler) tlsSecretKeys(obj client.Object) []string {
+	ingress, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return nil
+	}
+
+	var keys []string
+	seen := map[types.NamespacedName]bool{}
+	for _, tlsInstance := range ingress.Spec.TLS {
+		key, ok, err := r.secretKey(ingress.Namespace, tlsInstance.SecretName)
+		if !ok || err != nil || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key.String())
+	}
+
+	return keys
+}
+
+// ingressesForSecret enqueues the ingresses referencing the secret, so a renewed certificate is checked at once
+func (r *IngressTLSLogReconciler) ingressesForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	ingresses := &networkingv1.IngressList{}
+	key := types.NamespacedName{Namespace: secret.GetNamespace(), Name: secret.GetName()}
+	if err := r.List(ctx, ingresses, client.MatchingFields{tlsSecretIndex: key.String()}); err != nil {
+		logf.FromContext(ctx).Error(err, "unable to list the ingresses of the secret", "secret", key)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(ingresses.Items))
+	for _, ingress := range ingresses.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}})
+	}
+
+	return requests
+}
+
+// indexTLSSecrets registers tlsSecretIndex in the cache of the manager
+func (r *IngressTLSLogReconciler) indexTLSSecrets(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(context.Background(), &networkingv1.Ingress{}, tlsSecretIndex, r.tlsSecretKeys)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// countingReader counts the reads of the API server
+type countingReader struct {
+	client.Reader
+	gets int
+}
+
+func (c *countingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.gets++
+	return c.Reader.Get(ctx, key, obj, opts...)
+}
+
+var _ = Describe("Secret cache", func() {
+	ctx := context.Background()
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tls"},
+		Type:       v1.SecretTypeTLS,
+		Data:       map[string][]byte{v1.TLSCertKey: []byte("crt"), v1.TLSPrivateKeyKey: []byte("key")},
+	}
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{SecretName: "tls", Hosts: []string{"foo.com"}}, {SecretName: "other"}},
+		},
+	}
+
+	It("should only read the secrets with cached metadata from the API server", func() {
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build()
+		apiReader := &countingReader{Reader: c}
+		r := &IngressTLSLogReconciler{Client: c, MetadataOnlySecrets: true, APIReader: apiReader, SecretMetadataReader: c}
+
+		read := &v1.Secret{}
+		Expect(r.secretReader().Get(ctx, types.NamespacedName{Namespace: "ns", Name: "tls"}, read)).To(Succeed())
+		Expect(read.Data).To(HaveKeyWithValue(v1.TLSCertKey, []byte("crt")))
+		Expect(apiReader.gets).To(Equal(1))
+
+		err := r.secretReader().Get(ctx, types.NamespacedName{Namespace: "ns", Name: "missing"}, &v1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(apiReader.gets).To(Equal(1))
+
+		Expect(r.secretReader().Get(ctx, types.NamespacedName{Namespace: "ns", Name: "web"}, &networkingv1.Ingress{})).NotTo(Succeed())
+		Expect(apiReader.gets).To(Equal(1))
+
+		// The unchanged secret is not read again for its certificate
+		crt, ok := r.Certificate(ctx, types.NamespacedName{Namespace: "ns", Name: "tls"})
+		Expect(ok).To(BeTrue())
+		Expect(crt).To(Equal([]byte("crt")))
+		Expect(apiReader.gets).To(Equal(1))
+
+		_, ok = r.Certificate(ctx, types.NamespacedName{Namespace: "ns", Name: "missing"})
+		Expect(ok).To(BeFalse())
+		Expect(apiReader.gets).To(Equal(1))
+
+		// The checkers need the key, so they always read the secret
+		Expect(r.secretReader().Get(ctx, types.NamespacedName{Namespace: "ns", Name: "tls"}, read)).To(Succeed())
+		Expect(read.Data).To(HaveKey(v1.TLSPrivateKeyKey))
+		Expect(apiReader.gets).To(Equal(2))
+
+		// A changed secret is read again
+		changed := secret.DeepCopy()
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "tls"}, changed)).To(Succeed())
+		changed.Data[v1.TLSCertKey] = []byte("renewed")
+		Expect(c.Update(ctx, changed)).To(Succeed())
+		crt, ok = r.Certificate(ctx, types.NamespacedName{Namespace: "ns", Name: "tls"})
+		Expect(ok).To(BeTrue())
+		Expect(crt).To(Equal([]byte("renewed")))
+		Expect(apiReader.gets).To(Equal(3))
+	})
+
+	It("should read the metadata of the secrets from the cache with a client not caching the secrets", func() {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			read := secret.DeepCopy()
+			read.APIVersion, read.Kind = "v1", "Secret"
+			w.Header().Set("Content-Type", "application/json")
+			Expect(json.NewEncoder(w).Encode(read)).To(Succeed())
+		}))
+		defer server.Close()
+
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(v1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+		cache := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build()
+		c, err := client.New(&rest.Config{Host: server.URL}, client.Options{
+			Scheme: clientgoscheme.Scheme,
+			Mapper: mapper,
+			Cache:  &client.CacheOptions{Reader: cache, DisableFor: []client.Object{&v1.Secret{}}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		r := &IngressTLSLogReconciler{Client: c, MetadataOnlySecrets: true, APIReader: c, SecretMetadataReader: cache}
+
+		read := &v1.Secret{}
+		Expect(r.secretReader().Get(ctx, types.NamespacedName{Namespace: "ns", Name: "tls"}, read)).To(Succeed())
+		Expect(read.Data).To(HaveKeyWithValue(v1.TLSCertKey, []byte("crt")))
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+
+		err = r.secretReader().Get(ctx, types.NamespacedName{Namespace: "ns", Name: "missing"}, &v1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should enqueue the ingresses referencing a secret", func() {
+		r := &IngressTLSLogReconciler{}
+		r.Client = fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).
+			WithObjects(ingress, &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "web"}}).
+			WithIndex(&networkingv1.Ingress{}, tlsSecretIndex, r.tlsSecretKeys).
+			Build()
+
+		Expect(r.tlsSecretKeys(ingress)).To(Equal([]string{"ns/tls", "ns/other"}))
+		Expect(r.ingressesForSecret(ctx, secret)).To(ConsistOf(HaveField("NamespacedName", types.NamespacedName{Namespace: "ns", Name: "web"})))
+	})
+
+	It("should drop the key of the secrets in certificate only mode", func() {
+		crtOnly := secret.DeepCopy()
+		crtOnly.Name = "other"
+		delete(crtOnly.Data, v1.TLSPrivateKeyKey)
+
+		deps := &CheckDeps{
+			Client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret, crtOnly).Build(),
+			SecretKey: func(ingressNamespace, secretName string) (types.NamespacedName, bool, error) {
+				return resolveSecretKey(ingressNamespace, secretName, false, nil)
+			},
+			CertificateOnly: true,
+		}
+
+		_, read, ok, err := deps.Secret(ctx, ingress, "tls")
+		Expect(ok).To(BeTrue())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(read.Data).NotTo(HaveKey(v1.TLSPrivateKeyKey))
+
+		crt, key, ok := deps.TLSSecret(ctx, ingress, "other")
+		Expect(ok).To(BeTrue())
+		Expect(crt).To(Equal([]byte("crt")))
+		Expect(key).To(BeNil())
+		Expect(checkSecret(ctx, ingress, deps)).To(BeEmpty())
+
+		deps = &CheckDeps{Client: deps.Client, SecretKey: deps.SecretKey}
+		Expect(checkSecret(ctx, ingress, deps)).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ParseSecretReference parses a secret reference in the namespace/name syntax
+func ParseSecretReference(ref string) (types.NamespacedName, error) {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return types.NamespacedName{}, fmt.Errorf("invalid secret reference %q, expected namespace/name", ref)
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// secretKey resolves the secret referenced by the secretName of a TLS block with the options of the reconciler
+func (r *IngressTLSLogReconciler) secretKey(ingressNamespace, secretName string) (types.NamespacedName, bool, error) {
+	return resolveSecretKey(ingressNamespace, secretName, r.AllowCrossNamespaceSecrets, r.DefaultCertificate)
+}
+
+// resolveSecretKey resolves the secret referenced by the secretName of a TLS block in the ingress namespace.
+// If allowed, the secretName may reference another namespace as namespace/name.
+// An empty secretName resolves to the default certificate of the ingress controller if it is configured.
+// The boolean is false if the secretName is empty and no default certificate is configured.
+func resolveSecretKey(
+	ingressNamespace, secretName string,
+	allowCrossNamespace bool,
+	defaultCertificate *types.NamespacedName,
+) (types.NamespacedName, bool, error) {
+	if secretName == "" {
+		if defaultCertificate == nil {
+			return types.NamespacedName{}, false, nil
+		}
+		return *defaultCertificate, true, nil
+	}
+
+	if allowCrossNamespace && strings.Contains(secretName, "/") {
+		key, err := ParseSecretReference(secretName)
+		return key, true, err
+	}
+
+	return types.NamespacedName{Namespace: ingressNamespace, Name: secretName}, true, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Secret reference", func() {
+	It("should parse the namespace/name syntax", func() {
+		key, err := ParseSecretReference("ingress-nginx/default-tls")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal(types.NamespacedName{Namespace: "ingress-nginx", Name: "default-tls"}))
+
+		for _, ref := range []string{"default-tls", "/default-tls", "ingress-nginx/", "a/b/c"} {
+			_, err = ParseSecretReference(ref)
+			Expect(err).To(HaveOccurred(), ref)
+		}
+	})
+
+	It("should resolve the secret of a TLS block", func() {
+		r := &IngressTLSLogReconciler{}
+
+		By("resolving the secretName in the ingress namespace")
+		key, ok, err := r.secretKey("default", "ns/secret-tls")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal(types.NamespacedName{Namespace: "default", Name: "ns/secret-tls"}))
+
+		By("resolving the secretName in another namespace when allowed")
+		r.AllowCrossNamespaceSecrets = true
+		key, _, err = r.secretKey("default", "ns/secret-tls")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal(types.NamespacedName{Namespace: "ns", Name: "secret-tls"}))
+
+		By("resolving an empty secretName without default certificate")
+		_, ok, _ = r.secretKey("default", "")
+		Expect(ok).To(BeFalse())
+
+		By("resolving an empty secretName to the default certificate")
+		r.DefaultCertificate = &types.NamespacedName{Namespace: "ingress-nginx", Name: "default-tls"}
+		key, ok, err = r.secretKey("default", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal(*r.DefaultCertificate))
+	})
+})
@@ -0,0 +1,226 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/certmanager"
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+// Finding is a failed check of an ingress
+type Finding struct {
+	// Namespace is the namespace of the ingress
+	Namespace string `json:"namespace"`
+	// Ingress is the name of the ingress
+	Ingress string `json:"ingress"`
+	// Host is the ingress host, empty if the finding is about the whole ingress
+	Host string `json:"host,omitempty"`
+	// Reason is the reason of the failed check
+	Reason string `json:"reason"`
+	// Category tells who should act on the finding
+	Category string `json:"category"`
+	// Severity is Error or Warn
+	Severity string `json:"severity"`
+	// Message describes the finding
+	Message string `json:"message"`
+	// Fingerprint is the fingerprint of the certificate in the secret, empty if the finding is not about it
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// StaticOptions configures the static checks like the corresponding fields of the reconciler
+type StaticOptions struct {
+	// AllowCrossNamespaceSecrets parses secretName as namespace/name
+	AllowCrossNamespaceSecrets bool
+	// DefaultCertificate is the secret used for TLS blocks without secretName
+	DefaultCertificate *types.NamespacedName
+	// ExpiryWarning reports certificates expiring within this duration as Warn
+	ExpiryWarning time.Duration
+	// Now is the time the certificates are checked at
+	Now time.Time
+	// Checkers are the checkers run on the ingresses, in default the built-in ones
+	Checkers *CheckerRegistry
+}
+
+// SecretLookup returns the secret with the key, the boolean is false if it is unknown
+type SecretLookup func(key types.NamespacedName) (*v1.Secret, bool)
+
+// CheckIngress runs the enabled checkers of the reconciler on the ingress without the cluster or the network.
+// Unlike the reconciler, it reports the findings of every checker instead of the first failing one.
+// The cert-manager check is skipped, and the handshake is replaced by a check of the validity of the
+// certificates which also reports the ones expiring within ExpiryWarning as Warn.
+// Missing secrets are reported as Warn because they are often created outside of the manifests,
+// and not at all if cert-manager issues them.
+func CheckIngress(ingress *networkingv1.Ingress, secrets SecretLookup, opts StaticOptions) []Finding {
+	checkers := opts.Checkers
+	if checkers == nil {
+		checkers = defaultCheckers()
+	}
+
+	deps := &CheckDeps{
+		Client: lookupReader(secrets),
+		SecretKey: func(ingressNamespace, secretName string) (types.NamespacedName, bool, error) {
+			return resolveSecretKey(ingressNamespace, secretName, opts.AllowCrossNamespaceSecrets, opts.DefaultCertificate)
+		},
+		Now: opts.Now,
+	}
+
+	// The static replacements of the checkers needing the network
+	static := map[string]CheckFunc{
+		SecretChecker:       checkManifestSecret,
+		TLSHandshakeChecker: certificateValidityCheck(opts.Now, opts.ExpiryWarning),
+	}
+
+	ctx := context.Background()
+	var findings []Finding
+	for _, checker := range checkers.Enabled() {
+		if check, ok := static[checker.Name()]; ok {
+			findings = append(findings, check(ctx, ingress, deps)...)
+			continue
+		}
+
+		findings = append(findings, checker.Check(ctx, ingress, deps)...)
+	}
+
+	return findings
+}
+
+// checkManifestSecret reports the secrets of the manifests which are no TLS secrets with crt and key.
+// Unlike checkSecret, a missing secret is a Warn, and no finding if cert-manager issues it.
+func checkManifestSecret(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+	var findings []Finding
+	for _, tlsInstance := range ingress.Spec.TLS {
+		key, secret, ok, err := deps.Secret(ctx, ingress, tlsInstance.SecretName)
+		if !ok {
+			continue
+		}
+
+		_, _, keyErr := deps.SecretKey(ingress.Namespace, tlsInstance.SecretName)
+		switch {
+		case keyErr != nil:
+			// Invalid references are reported by the secret-name checker
+			continue
+		case apierrors.IsNotFound(err):
+			// cert-manager issues the secret once the ingress is applied
+			if !certmanager.Manages(ingress.Annotations) || tlsInstance.SecretName == "" {
+				finding := NewFinding(ingress, "", ErrFetchSecret, fmt.Errorf("the secret %s is not in the manifests", key))
+				finding.Severity = WarnLogLevel
+				findings = append(findings, finding)
+			}
+		case err != nil:
+			findings = append(findings, NewFinding(ingress, "", ErrFetchSecret, err))
+		case !deps.validTLSSecret(secret):
+			findings = append(findings, NewFinding(ingress, "", ErrCrtOrKeyMissing, nil))
+		}
+	}
+
+	return findings
+}
+
+// certificateValidityCheck returns the check of the validity period of the certificates in the secrets at now.
+// Certificates expiring within expiryWarning are reported as Warn.
+func certificateValidityCheck(now time.Time, expiryWarning time.Duration) CheckFunc {
+	return func(ctx context.Context, ingress *networkingv1.Ingress, deps *CheckDeps) []Finding {
+		var findings []Finding
+		seen := map[types.NamespacedName]bool{}
+		for _, tlsInstance := range ingress.Spec.TLS {
+			key, _, _, _ := deps.Secret(ctx, ingress, tlsInstance.SecretName)
+			crt, _, ok := deps.TLSSecret(ctx, ingress, tlsInstance.SecretName)
+			if !ok || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			// Invalid certificates are reported by the certificate-san checker
+			cert, err := utils.ParseCertificate(crt)
+			if err != nil {
+				continue
+			}
+
+			var finding Finding
+			switch {
+			case now.After(cert.NotAfter):
+				finding = NewFinding(ingress, "", ErrCertificateExpired,
+					fmt.Errorf("the certificate in the secret %s expired at %s", key, cert.NotAfter.Format(time.RFC3339)))
+			case now.Before(cert.NotBefore):
+				finding = NewFinding(ingress, "", ErrCertificateNotYetValid,
+					fmt.Errorf("the certificate in the secret %s is not valid before %s", key, cert.NotBefore.Format(time.RFC3339)))
+			case now.Add(expiryWarning).After(cert.NotAfter):
+				finding = NewFinding(ingress, "", ErrCertificateExpiringSoon,
+					fmt.Errorf("the certificate in the secret %s expires at %s", key, cert.NotAfter.Format(time.RFC3339)))
+				finding.Severity = WarnLogLevel
+			default:
+				continue
+			}
+
+			finding.Fingerprint = utils.Fingerprint(crt)
+			findings = append(findings, finding)
+		}
+
+		return findings
+	}
+}
+
+// lookupReader reads the secrets of a SecretLookup, the unknown ones are not found
+type lookupReader SecretLookup
+
+// Get copies the secret with the key into obj, which must be a secret
+func (l lookupReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	target, ok := obj.(*v1.Secret)
+	if !ok {
+		return fmt.Errorf("unable to read %T from the manifests", obj)
+	}
+
+	secret, ok := l(key)
+	if !ok {
+		return apierrors.NewNotFound(v1.Resource("secrets"), key.Name)
+	}
+
+	secret.DeepCopyInto(target)
+	return nil
+}
+
+// List is not supported, the checkers only get the secrets
+func (l lookupReader) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	return fmt.Errorf("unable to list %T from the manifests", list)
+}
+
+// hasHTTPRedirect reports whether the ingress redirects HTTP traffic through its annotations
+func hasHTTPRedirect(ingress *networkingv1.Ingress) bool {
+	for key, value := range ingress.Annotations {
+		if strings.Contains(key, "permanent-redirect") || strings.Contains(key, "temporary-redirect") {
+			return true
+		}
+
+		if strings.Contains(key, "configuration-snippet") &&
+			(strings.Contains(value, "301") || strings.Contains(value, "302")) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// forgetIngress removes all store entries of a deleted ingress, its open finding is notified as resolved
+func (r *IngressTLSLogReconciler) forgetIngress(key string) {
+	namespace, name, _ := strings.Cut(key, "/")
+	r.resolveIngressError(namespace, name, key)
+	r.forgetFinding(key)
+	r.IngressUpdateTimeMap.Delete(key)
+}
+
+// sweepStores removes the store entries of ingresses no longer in the cache after every interval until ctx is done
+func (r *IngressTLSLogReconciler) sweepStores(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("store-sweep")
+
+	interval := r.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			removed, err := r.sweepStoresOnce(ctx)
+			if err != nil {
+				log.Error(err, "failed to sweep the stores")
+				continue
+			}
+			if removed != 0 {
+				log.Info("removed the store entries of deleted ingresses", "ingresses", removed)
+			}
+		}
+	}
+}
+
+// sweepStoresOnce removes the store entries of ingresses no longer in the cache and returns their number.
+// The keys are collected before the ingresses are listed, and the entries updated after the List are kept,
+// so an ingress reconciled while the sweep runs is not forgotten.
+func (r *IngressTLSLogReconciler) sweepStoresOnce(ctx context.Context) (int, error) {
+	keys := map[string]bool{}
+	for key := range r.IngressErrorMap.List() {
+		keys[key] = true
+	}
+	for key := range r.IngressUpdateTimeMap.List() {
+		keys[key] = true
+	}
+	if r.IngressFindingMap != nil {
+		for key := range r.IngressFindingMap.List() {
+			keys[key] = true
+		}
+	}
+
+	listed := time.Now()
+	ingresses := &networkingv1.IngressList{}
+	if err := r.List(ctx, ingresses); err != nil {
+		return 0, err
+	}
+
+	existing := make(map[string]bool, len(ingresses.Items))
+	for _, ingress := range ingresses.Items {
+		existing[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}.String()] = true
+	}
+
+	removed := 0
+	for key := range keys {
+		if existing[key] {
+			continue
+		}
+		if updateTime, ok := r.IngressUpdateTimeMap.Get(key); ok && updateTime.After(listed) {
+			continue
+		}
+
+		r.forgetIngress(key)
+		removed++
+	}
+
+	return removed, nil
+}
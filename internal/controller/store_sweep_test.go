@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/notify"
+	"github.com/MMMMMMorty/ingress-auditor/internal/store"
+)
+
+var _ = Describe("Store sweep", func() {
+	It("should remove the store entries of ingresses no longer in the cache", func() {
+		r := &IngressTLSLogReconciler{
+			Client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+				&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "existing"}},
+			).Build(),
+			IngressErrorMap:      store.NewIngressErrorMap(),
+			IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
+			IngressFindingMap:    store.NewIngressFindingMap(),
+		}
+
+		for _, key := range []string{"ns/existing", "ns/deleted"} {
+			r.IngressErrorMap.Set(key, ErrHTTPRedirectMissing)
+			r.IngressUpdateTimeMap.Set(key, time.Now())
+			r.IngressFindingMap.Set(key, store.Finding{FirstSeen: time.Now()})
+		}
+		// A deleted ingress may only be left in one of the stores
+		r.IngressUpdateTimeMap.Set("ns/resolved", time.Now())
+
+		removed, err := r.sweepStoresOnce(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(Equal(2))
+
+		Expect(r.IngressErrorMap.List()).To(HaveKey("ns/existing"))
+		Expect(r.IngressErrorMap.List()).NotTo(HaveKey("ns/deleted"))
+		Expect(r.IngressUpdateTimeMap.List()).To(HaveLen(1))
+		Expect(r.IngressFindingMap.List()).To(HaveLen(1))
+	})
+
+	It("should notify the open finding of a deleted ingress as resolved", func() {
+		out := &bytes.Buffer{}
+		r := &IngressTLSLogReconciler{
+			Client:               fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build(),
+			IngressErrorMap:      store.NewIngressErrorMap(),
+			IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
+			Notifier:             notify.NewDispatcher(logr.Discard(), time.Second, notify.NewStreamSink("stream", out, "")),
+		}
+		r.IngressErrorMap.Set("ns/deleted", ErrHTTPRedirectMissing)
+		r.IngressUpdateTimeMap.Set("ns/deleted", time.Now().Add(-time.Minute))
+
+		removed, err := r.sweepStoresOnce(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(Equal(1))
+
+		event := notify.Event{}
+		Expect(json.Unmarshal(out.Bytes(), &event)).To(Succeed())
+		Expect(event.Type).To(Equal(notify.EventResolved))
+		Expect(event.Namespace).To(Equal("ns"))
+		Expect(event.Ingress).To(Equal("deleted"))
+		Expect(event.Reason).To(Equal(ReasonFor(ErrHTTPRedirectMissing).Code))
+	})
+
+	It("should keep the entries updated after the ingresses were listed", func() {
+		r := &IngressTLSLogReconciler{
+			IngressErrorMap:      store.NewIngressErrorMap(),
+			IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
+		}
+		r.IngressErrorMap.Set("ns/created", ErrHTTPRedirectMissing)
+		r.IngressUpdateTimeMap.Set("ns/created", time.Now().Add(-time.Minute))
+
+		// The ingress is reconciled while the stale cache is listed
+		r.Client = interceptor.NewClient(fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build(), interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				r.IngressUpdateTimeMap.Set("ns/created", time.Now().Add(time.Second))
+				return c.List(ctx, list, opts...)
+			},
+		})
+
+		removed, err := r.sweepStoresOnce(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(BeZero())
+		Expect(r.IngressErrorMap.List()).To(HaveKey("ns/created"))
+	})
+})
@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Summary counts the open findings, e.g. to tune the policy in a dry run
+type Summary struct {
+	// Total is the number of ingresses with an open finding
+	Total int `json:"total"`
+	// ByReason counts the open findings by their reason
+	ByReason map[string]int `json:"byReason"`
+	// BySeverity counts the open findings by their severity
+	BySeverity map[string]int `json:"bySeverity"`
+	// ByNamespace counts the open findings by the namespace of their ingress
+	ByNamespace map[string]int `json:"byNamespace"`
+}
+
+// SummarizeFindings counts the open findings
+func SummarizeFindings(findings []OpenFinding) Summary {
+	summary := Summary{
+		Total:       len(findings),
+		ByReason:    map[string]int{},
+		BySeverity:  map[string]int{},
+		ByNamespace: map[string]int{},
+	}
+
+	for _, finding := range findings {
+		summary.ByReason[finding.Reason]++
+		summary.BySeverity[finding.Severity]++
+		summary.ByNamespace[finding.Namespace]++
+	}
+
+	return summary
+}
+
+// openFindingsDesc describes the gauge of the open findings
+var openFindingsDesc = prometheus.NewDesc(
+	"ingress_audit_open_findings",
+	"Number of ingresses with an open finding, by namespace, reason and severity.",
+	[]string{"namespace", "reason", "severity"}, nil,
+)
+
+// openFindings exports the open findings of the reconcilers set up with a manager.
+// It is registered once, setting up another reconciler adds it to the collector.
+var openFindings = &openFindingsCollector{}
+
+func init() {
+	metrics.Registry.MustRegister(openFindings)
+}
+
+// openFindingsCollector exports the open findings of the reconcilers when the metrics are scraped
+type openFindingsCollector struct {
+	mu          sync.Mutex
+	reconcilers []*IngressTLSLogReconciler
+}
+
+// add exports the open findings of the reconciler
+func (c *openFindingsCollector) add(reconciler *IngressTLSLogReconciler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reconcilers = append(c.reconcilers, reconciler)
+}
+
+// Describe sends the description of the gauge
+func (c *openFindingsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- openFindingsDesc
+}
+
+// Collect counts the open findings by namespace, reason and severity
+func (c *openFindingsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	reconcilers := append([]*IngressTLSLogReconciler(nil), c.reconcilers...)
+	c.mu.Unlock()
+
+	type labels struct{ namespace, reason, severity string }
+	counts := map[labels]int{}
+	for _, reconciler := range reconcilers {
+		for _, finding := range reconciler.OpenFindings() {
+			counts[labels{finding.Namespace, finding.Reason, finding.Severity}]++
+		}
+	}
+
+	for l, count := range counts {
+		ch <- prometheus.MustNewConstMetric(openFindingsDesc, prometheus.GaugeValue, float64(count), l.namespace, l.reason, l.severity)
+	}
+}
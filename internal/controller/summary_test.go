@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/store"
+)
+
+var _ = Describe("Open findings metric", func() {
+	It("should export the open findings of every reconciler set up", func() {
+		testScheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+		Expect(ingressauditv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		ctx := context.Background()
+
+		collector := &openFindingsCollector{}
+		var severity string
+		for _, namespace := range []string{"team-a", "team-b"} {
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "web", UID: types.UID("uid-" + namespace)}}
+			r := &IngressTLSLogReconciler{
+				Client:               fake.NewClientBuilder().WithScheme(testScheme).WithObjects(ingress).Build(),
+				Scheme:               testScheme,
+				IngressErrorMap:      store.NewIngressErrorMap(),
+				IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
+				IngressFindingMap:    store.NewIngressFindingMap(),
+				DryRun:               true,
+			}
+			Expect(r.logErrorAndUpdateMaps(ctx, ingress, namespace, "web", findingDetail{}, ErrHTTPRedirectMissing, namespace+"/web")).To(Succeed())
+			severity = r.OpenFindings()[0].Severity
+			collector.add(r)
+		}
+
+		reason := ReasonFor(ErrHTTPRedirectMissing).Code
+		expected := `
+# HELP ingress_audit_open_findings Number of ingresses with an open finding, by namespace, reason and severity.
+# TYPE ingress_audit_open_findings gauge
+ingress_audit_open_findings{namespace="team-a",reason="` + reason + `",severity="` + severity + `"} 1
+ingress_audit_open_findings{namespace="team-b",reason="` + reason + `",severity="` + severity + `"} 1
+`
+		Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected))).To(Succeed())
+	})
+})
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+)
+
+// Modes of writing the ingresstlslogs instances
+const (
+	// LogModeAppend creates a new instance every time an error is logged
+	LogModeAppend = "append"
+	// LogModeUpsert keeps one instance per ingress, host and reason, updated every time the error is logged
+	LogModeUpsert = "upsert"
+)
+
+// upsertLogName returns the deterministic name of the ingresstlslogs instance of the ingress, host and reason
+func upsertLogName(ingressNamespace, ingressName, host, reason string) string {
+	sum := sha256.Sum256([]byte(ingressNamespace + "/" + ingressName + "/" + host + "/" + reason))
+	return logName(ingressNamespace+"-"+ingressName, hex.EncodeToString(sum[:])[:10])
+}
+
+// logName joins the prefix and the unique suffix of the name of an ingresstlslogs instance.
+// The prefix is truncated so the name does not exceed the maximum length of an object name.
+func logName(prefix, suffix string) string {
+	if maxPrefix := validation.DNS1123SubdomainMaxLength - len(suffix) - 1; len(prefix) > maxPrefix {
+		// The name must not contain a dot or dash next to the dash before the suffix
+		prefix = strings.TrimRight(prefix[:maxPrefix], ".-")
+	}
+
+	return prefix + "-" + suffix
+}
+
+// upsertTLSLog creates the ingresstlslogs instance under its deterministic name, or updates it in place if it exists.
+// The status records when the error was first and last logged and how often.
+func (r *IngressTLSLogReconciler) upsertTLSLog(ctx context.Context, TLSLog *ingressauditv1alpha1.IngressTLSLog) error {
+	TLSLog.Name = upsertLogName(TLSLog.Namespace, TLSLog.Spec.IngressName, TLSLog.Spec.Host, TLSLog.Spec.Reason)
+	seen := *TLSLog.Spec.GenerationTimestamp
+
+	existing := &ingressauditv1alpha1.IngressTLSLog{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: TLSLog.Namespace, Name: TLSLog.Name}, existing)
+	if apierrors.IsNotFound(err) {
+		if err := r.Create(ctx, TLSLog); err != nil {
+			return err
+		}
+		return r.recordOccurrence(ctx, TLSLog, seen, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The previous occurrence is the first one if the status was never written
+	previous := existing.Spec.GenerationTimestamp
+	if previous == nil {
+		previous = &metav1.Time{Time: existing.CreationTimestamp.Time}
+	}
+
+	existing.Spec = TLSLog.Spec
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	for key, value := range TLSLog.Labels {
+		existing.Labels[key] = value
+	}
+	if err := r.Update(ctx, existing); err != nil {
+		return err
+	}
+
+	if err := r.recordOccurrence(ctx, existing, seen, previous); err != nil {
+		return err
+	}
+
+	existing.DeepCopyInto(TLSLog)
+	return nil
+}
+
+// recordOccurrence sets FirstSeen, LastSeen and OccurrenceCount of the occurrence at seen in one status update.
+// previous is the occurrence before it, nil if the instance was just created. The update is retried on
+// transient errors, and on conflicts with the instance read again.
+func (r *IngressTLSLogReconciler) recordOccurrence(
+	ctx context.Context,
+	TLSLog *ingressauditv1alpha1.IngressTLSLog,
+	seen metav1.Time,
+	previous *metav1.Time,
+) error {
+	base := TLSLog.DeepCopy()
+	return retry.OnError(retry.DefaultBackoff, isRetriableStatusError, func() error {
+		updated := base.DeepCopy()
+		count := updated.Status.OccurrenceCount
+		firstSeen := seen
+		if previous != nil {
+			// The instance was created before, even if the status write of its create failed
+			count = max(count, 1)
+			firstSeen = *previous
+		}
+		if updated.Status.FirstSeen == nil {
+			updated.Status.FirstSeen = &firstSeen
+		}
+		updated.Status.LastSeen = &seen
+		updated.Status.OccurrenceCount = count + 1
+
+		err := r.Status().Update(ctx, updated)
+		switch {
+		case err == nil:
+			updated.DeepCopyInto(TLSLog)
+		case apierrors.IsConflict(err):
+			if getErr := r.Get(ctx, types.NamespacedName{Namespace: base.Namespace, Name: base.Name}, base); getErr != nil {
+				return getErr
+			}
+		}
+		return err
+	})
+}
+
+// isRetriableStatusError reports whether a failed status update is retried
+func isRetriableStatusError(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+)
+
+var _ = Describe("Upsert mode", func() {
+	newLog := func(message, level string, generated time.Time) *ingressauditv1alpha1.IngressTLSLog {
+		TLSLog := &ingressauditv1alpha1.IngressTLSLog{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "random"},
+			Spec: ingressauditv1alpha1.IngressTLSLogSpec{
+				LogLevel:            level,
+				NameSpace:           "ns",
+				IngressName:         "web",
+				Message:             message,
+				Reason:              "HostnameMismatch",
+				Host:                "foo.com",
+				GenerationTimestamp: &metav1.Time{Time: generated},
+			},
+		}
+		TLSLog.Labels = logLabels(TLSLog.Spec)
+		return TLSLog
+	}
+
+	It("should derive the name from the ingress, host and reason", func() {
+		name := upsertLogName("ns", "web", "foo.com", "HostnameMismatch")
+		Expect(name).To(HavePrefix("ns-web-"))
+		Expect(name).To(Equal(upsertLogName("ns", "web", "foo.com", "HostnameMismatch")))
+		Expect(name).NotTo(Equal(upsertLogName("ns", "web", "bar.com", "HostnameMismatch")))
+		Expect(name).NotTo(Equal(upsertLogName("ns", "web", "foo.com", "DNSResolution")))
+	})
+
+	It("should keep the names of ingresses with maximum length names valid and unique", func() {
+		namespace := strings.Repeat("n", validation.DNS1123LabelMaxLength)
+		// The truncated prefix would end in a dot
+		ingressName := strings.Repeat("w", 240-len(namespace)) + "." + strings.Repeat("w", 12)
+		Expect(ingressName).To(HaveLen(253 - len(namespace)))
+
+		name := upsertLogName(namespace, ingressName, "foo.com", "HostnameMismatch")
+		Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+		Expect(name).To(HaveLen(validation.DNS1123SubdomainMaxLength - 1))
+		Expect(name).NotTo(Equal(upsertLogName(namespace, ingressName, "bar.com", "HostnameMismatch")))
+
+		name = logName(namespace+"-"+strings.Repeat("w", 253), "2025-12-12-00-00-00-0123abcd")
+		Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+		Expect(name).To(HaveSuffix("-2025-12-12-00-00-00-0123abcd"))
+	})
+
+	It("should create the log once and update it in place", func() {
+		testScheme := runtime.NewScheme()
+		Expect(ingressauditv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		r := &IngressTLSLogReconciler{
+			Client: fake.NewClientBuilder().WithScheme(testScheme).
+				WithStatusSubresource(&ingressauditv1alpha1.IngressTLSLog{}).Build(),
+			LogMode: LogModeUpsert,
+		}
+		ctx := context.Background()
+		first := time.Now().Add(-time.Hour).Truncate(time.Second)
+		last := time.Now().Truncate(time.Second)
+
+		Expect(r.upsertTLSLog(ctx, newLog("first", ErrLogLevel, first))).To(Succeed())
+		Expect(r.upsertTLSLog(ctx, newLog("second", WarnLogLevel, last))).To(Succeed())
+
+		logs := &ingressauditv1alpha1.IngressTLSLogList{}
+		Expect(r.List(ctx, logs)).To(Succeed())
+		Expect(logs.Items).To(HaveLen(1))
+
+		TLSLog := &ingressauditv1alpha1.IngressTLSLog{}
+		key := types.NamespacedName{Namespace: "ns", Name: upsertLogName("ns", "web", "foo.com", "HostnameMismatch")}
+		Expect(r.Get(ctx, key, TLSLog)).To(Succeed())
+		Expect(TLSLog.Spec.Message).To(Equal("second"))
+		Expect(TLSLog.Status.FirstSeen.Time).To(BeTemporally("==", first))
+		Expect(TLSLog.Status.LastSeen.Time).To(BeTemporally("==", last))
+		Expect(TLSLog.Status.OccurrenceCount).To(Equal(int64(2)))
+		Expect(TLSLog.Labels).To(HaveKeyWithValue(SeverityLabel, WarnLogLevel))
+	})
+
+	Context("when the status write fails", func() {
+		var failures int
+		var r *IngressTLSLogReconciler
+		ctx := context.Background()
+		key := types.NamespacedName{Namespace: "ns", Name: upsertLogName("ns", "web", "foo.com", "HostnameMismatch")}
+
+		BeforeEach(func() {
+			failures = 0
+			testScheme := runtime.NewScheme()
+			Expect(ingressauditv1alpha1.AddToScheme(testScheme)).To(Succeed())
+			r = &IngressTLSLogReconciler{
+				Client: fake.NewClientBuilder().WithScheme(testScheme).
+					WithStatusSubresource(&ingressauditv1alpha1.IngressTLSLog{}).
+					WithInterceptorFuncs(interceptor.Funcs{
+						SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+							if failures > 0 {
+								failures--
+								return apierrors.NewServiceUnavailable("etcd is unavailable")
+							}
+							return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+						},
+					}).Build(),
+				LogMode: LogModeUpsert,
+			}
+		})
+
+		It("should retry the status write after a transient error", func() {
+			failures = 2
+			first := time.Now().Truncate(time.Second)
+			Expect(r.upsertTLSLog(ctx, newLog("first", ErrLogLevel, first))).To(Succeed())
+
+			TLSLog := &ingressauditv1alpha1.IngressTLSLog{}
+			Expect(r.Get(ctx, key, TLSLog)).To(Succeed())
+			Expect(TLSLog.Status.FirstSeen.Time).To(BeTemporally("==", first))
+			Expect(TLSLog.Status.OccurrenceCount).To(Equal(int64(1)))
+		})
+
+		It("should count the created log if its status was never written", func() {
+			failures = 100
+			first := time.Now().Add(-time.Hour).Truncate(time.Second)
+			last := time.Now().Truncate(time.Second)
+			Expect(r.upsertTLSLog(ctx, newLog("first", ErrLogLevel, first))).NotTo(Succeed())
+
+			failures = 0
+			Expect(r.upsertTLSLog(ctx, newLog("second", ErrLogLevel, last))).To(Succeed())
+
+			TLSLog := &ingressauditv1alpha1.IngressTLSLog{}
+			Expect(r.Get(ctx, key, TLSLog)).To(Succeed())
+			Expect(TLSLog.Status.FirstSeen.Time).To(BeTemporally("==", first))
+			Expect(TLSLog.Status.LastSeen.Time).To(BeTemporally("==", last))
+			Expect(TLSLog.Status.OccurrenceCount).To(Equal(int64(2)))
+		})
+	})
+})
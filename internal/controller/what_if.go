@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+)
+
+// PolicyDiff compares the findings of a candidate policy with the open findings
+type PolicyDiff struct {
+	// New are the findings only reported under the candidate policy
+	New []Finding `json:"new"`
+	// Removed are the open findings no longer reported under the candidate policy
+	Removed []Finding `json:"removed"`
+	// SeverityChanged are the open findings reported with another severity under the candidate policy
+	SeverityChanged []SeverityChange `json:"severityChanged"`
+}
+
+// SeverityChange is an open finding reported with another severity under the candidate policy
+type SeverityChange struct {
+	Finding
+	// CandidateSeverity is the severity under the candidate policy, Severity is the current one
+	CandidateSeverity string `json:"candidateSeverity"`
+}
+
+// WhatIf evaluates the candidate policy on the cached ingresses and secrets and compares its findings with the open findings.
+// The findings of the built-in checkers are taken from the stores, so no host is probed, only the rules of the candidate run.
+// The reconciler, its checkers and the reason catalog are not changed.
+func (r *IngressTLSLogReconciler) WhatIf(ctx context.Context, candidate *policy.Policy) (PolicyDiff, error) {
+	diff := PolicyDiff{New: []Finding{}, Removed: []Finding{}, SeverityChanged: []SeverityChange{}}
+
+	registry := r.checkers()
+	var rules []Checker
+	for i := range candidate.Rules {
+		rule := &candidate.Rules[i]
+		if registry.Get(rule.Name) != nil && !registry.IsEnabled(rule.Name) {
+			continue
+		}
+		rules = append(rules, newRuleChecker(rule, errors.New(rule.Message)))
+	}
+
+	builtinReasons := map[string]bool{}
+	for _, checker := range BuiltinCheckers() {
+		for _, code := range checker.Reasons() {
+			builtinReasons[code] = true
+		}
+	}
+
+	open := map[types.NamespacedName]OpenFinding{}
+	for _, finding := range r.OpenFindings() {
+		open[types.NamespacedName{Namespace: finding.Namespace, Name: finding.Ingress}] = finding
+	}
+
+	ingresses := &networkingv1.IngressList{}
+	if err := r.List(ctx, ingresses); err != nil {
+		return diff, err
+	}
+	sort.Slice(ingresses.Items, func(i, j int) bool {
+		if ingresses.Items[i].Namespace != ingresses.Items[j].Namespace {
+			return ingresses.Items[i].Namespace < ingresses.Items[j].Namespace
+		}
+		return ingresses.Items[i].Name < ingresses.Items[j].Name
+	})
+
+	log := logf.FromContext(ctx)
+	now := time.Now()
+	for i := range ingresses.Items {
+		// The rules are evaluated with the context of the request, stop once it is done
+		if err := ctx.Err(); err != nil {
+			return diff, err
+		}
+
+		ingress := &ingresses.Items[i]
+		if until, exempted, _ := ExemptUntil(ingress); exempted && now.Before(until) {
+			continue
+		}
+
+		current, hasCurrent := open[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}]
+
+		// The rules only run on ingresses passing the built-in checkers
+		var findings []Finding
+		if hasCurrent && builtinReasons[current.Reason] {
+			// The built-in checkers report Error, the current severity may be escalated by the active policy
+			finding := current.Finding
+			finding.Severity = ErrLogLevel
+			findings = []Finding{finding}
+		} else {
+			// The rules only need the tls.crt, which is not read again while the secret is unchanged
+			deps := r.checkDeps(log)
+			deps.Client = r.certificateReader()
+			deps.CertificateOnly = true
+			for _, rule := range rules {
+				if findings = rule.Check(ctx, ingress, deps); len(findings) != 0 {
+					break
+				}
+			}
+		}
+
+		switch {
+		case len(findings) == 0 && hasCurrent:
+			diff.Removed = append(diff.Removed, current.Finding)
+		case len(findings) == 0:
+		case !hasCurrent || current.Reason != findings[0].Reason:
+			if hasCurrent {
+				diff.Removed = append(diff.Removed, current.Finding)
+			}
+			finding := findings[0]
+			finding.Severity = candidateSeverity(candidate, r.Interval, finding.Severity, 0)
+			diff.New = append(diff.New, finding)
+		default:
+			severity := candidateSeverity(candidate, r.Interval, findings[0].Severity, now.Sub(current.FirstSeen))
+			if severity != current.Severity {
+				diff.SeverityChanged = append(diff.SeverityChanged, SeverityChange{Finding: current.Finding, CandidateSeverity: severity})
+			}
+		}
+	}
+
+	return diff, nil
+}
+
+// candidateSeverity returns the severity of a finding first seen age ago under the candidate policy.
+// Like in the reconciler, only Error findings are escalated.
+func candidateSeverity(candidate *policy.Policy, interval time.Duration, severity string, age time.Duration) string {
+	if severity != "" && severity != ErrLogLevel {
+		return severity
+	}
+
+	return candidate.Escalation.Severity(age, interval)
+}
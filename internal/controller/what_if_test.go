@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+	"github.com/MMMMMMorty/ingress-auditor/internal/store"
+)
+
+var _ = Describe("What if", func() {
+	It("should diff the findings of the candidate policy with the open findings", func() {
+		web := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", UID: "web"}}
+		api := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "api", UID: "api"}}
+		r := &IngressTLSLogReconciler{
+			Client:               fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(web, api).Build(),
+			Scheme:               clientgoscheme.Scheme,
+			IngressErrorMap:      store.NewIngressErrorMap(),
+			IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
+			IngressFindingMap:    store.NewIngressFindingMap(),
+			Interval:             time.Hour,
+			DryRun:               true,
+		}
+		ctx := context.Background()
+
+		Expect(r.logErrorAndUpdateMaps(ctx, web, "ns", "web", findingDetail{}, ErrHTTPRedirectMissing, "ns/web")).To(Succeed())
+
+		candidate, err := policy.Parse([]byte(`
+escalation:
+  escalateAfterIntervals: 2
+rules:
+- name: no-api
+  expression: ingress.metadata.name != "api"
+  reason: NoAPIWhatIfTest
+  severity: Info
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		diff, err := r.WhatIf(ctx, candidate)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Removed).To(BeEmpty())
+		Expect(diff.New).To(HaveLen(1))
+		Expect(diff.New[0].Ingress).To(Equal("api"))
+		Expect(diff.New[0].Reason).To(Equal("NoAPIWhatIfTest"))
+		Expect(diff.New[0].Severity).To(Equal(InfoLogLevel))
+		Expect(diff.SeverityChanged).To(HaveLen(1))
+		Expect(diff.SeverityChanged[0].Ingress).To(Equal("web"))
+		Expect(diff.SeverityChanged[0].Severity).To(Equal(ErrLogLevel))
+		Expect(diff.SeverityChanged[0].CandidateSeverity).To(Equal(WarnLogLevel))
+
+		_, registered := errTypeByCode("NoAPIWhatIfTest")
+		Expect(registered).To(BeFalse())
+	})
+
+	It("should not read unchanged secrets from the API server again with metadata only secrets", func() {
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tls"},
+			Type:       v1.SecretTypeTLS,
+			Data:       map[string][]byte{v1.TLSCertKey: []byte("crt"), v1.TLSPrivateKeyKey: []byte("key")},
+		}
+		newIngress := func(name string) *networkingv1.Ingress {
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, UID: types.UID(name)}}
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{SecretName: "tls", Hosts: []string{name + ".foo.com"}}}
+			return ingress
+		}
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret, newIngress("web"), newIngress("api")).Build()
+		apiReader := &countingReader{Reader: c}
+		r := &IngressTLSLogReconciler{
+			Client:               c,
+			Scheme:               clientgoscheme.Scheme,
+			IngressErrorMap:      store.NewIngressErrorMap(),
+			IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
+			IngressFindingMap:    store.NewIngressFindingMap(),
+			Interval:             time.Hour,
+			MetadataOnlySecrets:  true,
+			APIReader:            apiReader,
+			SecretMetadataReader: c,
+		}
+
+		candidate, err := policy.Parse([]byte(`
+rules:
+- name: secret-type
+  expression: secrets.all(s, s.type == "kubernetes.io/tls")
+  reason: SecretTypeWhatIfTest
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		for range 2 {
+			diff, err := r.WhatIf(context.Background(), candidate)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.New).To(BeEmpty())
+		}
+		Expect(apiReader.gets).To(Equal(1))
+
+		kept, ok := r.certificates.Load(types.NamespacedName{Namespace: "ns", Name: "tls"})
+		Expect(ok).To(BeTrue())
+		Expect(kept.(*v1.Secret).Data).NotTo(HaveKey(v1.TLSPrivateKeyKey))
+	})
+
+	It("should stop once the context of the request is done", func() {
+		web := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", UID: "web"}}
+		r := &IngressTLSLogReconciler{
+			Client:               fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(web).Build(),
+			Scheme:               clientgoscheme.Scheme,
+			IngressErrorMap:      store.NewIngressErrorMap(),
+			IngressUpdateTimeMap: store.NewIngressUpdateTimeMap(),
+			IngressFindingMap:    store.NewIngressFindingMap(),
+			Interval:             time.Hour,
+		}
+
+		candidate, err := policy.Parse([]byte(`
+rules:
+- name: no-web
+  expression: ingress.metadata.name != "web"
+  reason: NoWebWhatIfTest
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = r.WhatIf(ctx, candidate)
+		Expect(err).To(MatchError(context.Canceled))
+	})
+})
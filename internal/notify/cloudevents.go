@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Modes of the CloudEvents HTTP protocol binding
+const (
+	// CloudEventsStructured sends the whole event as the application/cloudevents+json body
+	CloudEventsStructured = "structured"
+	// CloudEventsBinary sends the attributes as ce- headers and the data as the body
+	CloudEventsBinary = "binary"
+)
+
+// cloudEventsTypePrefix prefixes the event type, e.g. dev.morty.ingress-audit.finding.created
+const cloudEventsTypePrefix = "dev.morty.ingress-audit.finding."
+
+// CloudEventsOptions configures a CloudEventsSink
+type CloudEventsOptions struct {
+	// URL is the endpoint of the broker
+	URL string
+	// Mode is structured or binary, structured is used if it is empty
+	Mode string
+	// Source identifies the auditor instance, e.g. the cluster name
+	Source string
+	// MaxRetries is the number of retries after the first failed attempt
+	MaxRetries int
+	// Backoff is the wait before the first retry, doubled for every further retry
+	Backoff time.Duration
+	// Client sends the requests, http.DefaultClient is used if it is nil
+	Client *http.Client
+}
+
+// CloudEventsSink sends the events as CloudEvents 1.0 over HTTP
+type CloudEventsSink struct {
+	opts CloudEventsOptions
+}
+
+// cloudEvent is the structured representation of a CloudEvent carrying an Event as data
+type cloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Event     `json:"data"`
+}
+
+// NewCloudEventsSink creates a CloudEventsSink
+func NewCloudEventsSink(opts CloudEventsOptions) (*CloudEventsSink, error) {
+	if opts.Mode == "" {
+		opts.Mode = CloudEventsStructured
+	}
+	if opts.Mode != CloudEventsStructured && opts.Mode != CloudEventsBinary {
+		return nil, fmt.Errorf("unknown CloudEvents mode %q", opts.Mode)
+	}
+	if opts.Source == "" {
+		opts.Source = "ingress-auditor"
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	return &CloudEventsSink{opts: opts}, nil
+}
+
+// Name identifies the sink in the logs
+func (c *CloudEventsSink) Name() string {
+	return "cloudevents " + endpoint(c.opts.URL)
+}
+
+// Send POSTs the event as a CloudEvent, retrying with backoff on network errors, 429 and 5xx
+func (c *CloudEventsSink) Send(ctx context.Context, event Event) error {
+	ce := cloudEvent{
+		SpecVersion:     "1.0",
+		ID:              uuid.NewString(),
+		Source:          c.opts.Source,
+		Type:            cloudEventsTypePrefix + event.Type,
+		Subject:         event.Namespace + "/" + event.Ingress,
+		Time:            event.Timestamp,
+		DataContentType: "application/json",
+		Data:            event,
+	}
+
+	var body []byte
+	var err error
+	if c.opts.Mode == CloudEventsStructured {
+		body, err = json.Marshal(ce)
+	} else {
+		body, err = json.Marshal(event)
+	}
+	if err != nil {
+		return err
+	}
+
+	return sendWithRetry(ctx, c.opts.Client, c.opts.MaxRetries, c.opts.Backoff, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.opts.URL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		if c.opts.Mode == CloudEventsStructured {
+			req.Header.Set("Content-Type", "application/cloudevents+json; charset=utf-8")
+			return req, nil
+		}
+
+		req.Header.Set("Content-Type", ce.DataContentType)
+		req.Header.Set("ce-specversion", ce.SpecVersion)
+		req.Header.Set("ce-id", ce.ID)
+		req.Header.Set("ce-source", ce.Source)
+		req.Header.Set("ce-type", ce.Type)
+		req.Header.Set("ce-subject", ce.Subject)
+		req.Header.Set("ce-time", ce.Time.UTC().Format(time.RFC3339Nano))
+		return req, nil
+	})
+}
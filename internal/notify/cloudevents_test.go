@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CloudEventsSink", func() {
+	var (
+		request *http.Request
+		body    []byte
+		server  *httptest.Server
+		event   Event
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+
+		event = Event{
+			Type:      EventResolved,
+			Timestamp: time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+			Namespace: "ns-1",
+			Ingress:   "ingress-1",
+			Reason:    "FetchSecret",
+			Severity:  "Info",
+			Message:   "unable to fetch secret",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should send the structured mode", func() {
+		sink, err := NewCloudEventsSink(CloudEventsOptions{URL: server.URL, Source: "cluster-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sink.Send(context.Background(), event)).To(Succeed())
+
+		Expect(request.Header.Get("Content-Type")).To(HavePrefix("application/cloudevents+json"))
+
+		received := cloudEvent{}
+		Expect(json.Unmarshal(body, &received)).To(Succeed())
+		Expect(received.SpecVersion).To(Equal("1.0"))
+		Expect(received.ID).NotTo(BeEmpty())
+		Expect(received.Source).To(Equal("cluster-1"))
+		Expect(received.Type).To(Equal("dev.morty.ingress-audit.finding.resolved"))
+		Expect(received.Subject).To(Equal("ns-1/ingress-1"))
+		Expect(received.Data).To(Equal(event))
+	})
+
+	It("should send the binary mode", func() {
+		sink, err := NewCloudEventsSink(CloudEventsOptions{URL: server.URL, Mode: CloudEventsBinary})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sink.Send(context.Background(), event)).To(Succeed())
+
+		Expect(request.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(request.Header.Get("ce-specversion")).To(Equal("1.0"))
+		Expect(request.Header.Get("ce-type")).To(Equal("dev.morty.ingress-audit.finding.resolved"))
+		Expect(request.Header.Get("ce-source")).To(Equal("ingress-auditor"))
+		Expect(request.Header.Get("ce-time")).To(Equal("2025-12-12T00:00:00Z"))
+
+		received := Event{}
+		Expect(json.Unmarshal(body, &received)).To(Succeed())
+		Expect(received).To(Equal(event))
+	})
+
+	It("should reject unknown modes", func() {
+		_, err := NewCloudEventsSink(CloudEventsOptions{URL: server.URL, Mode: "batched"})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// sendWithRetry sends the request created by newRequest, retrying with exponential backoff
+// on network errors, 429 and 5xx responses
+func sendWithRetry(
+	ctx context.Context,
+	client *http.Client,
+	maxRetries int,
+	backoff time.Duration,
+	newRequest func(ctx context.Context) (*http.Request, error),
+) error {
+	for attempt := 0; ; attempt++ {
+		retry, err := send(ctx, client, newRequest)
+		if err == nil || !retry || attempt >= maxRetries {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return fmt.Errorf("%w, last error: %w", ctx.Err(), err)
+		}
+		backoff *= 2
+	}
+}
+
+// send sends the request once and reports whether a failure is worth retrying
+func send(ctx context.Context, client *http.Client, newRequest func(ctx context.Context) (*http.Request, error)) (bool, error) {
+	req, err := newRequest(ctx)
+	if err != nil {
+		return false, redactURLError(err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, redactURLError(err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("%s responded with %s", endpoint(req.URL.String()), resp.Status)
+}
+
+// endpoint returns the scheme and host of the URL for the logs.
+// The path and query are left out, chat webhooks like Slack and Teams carry their token in them.
+func endpoint(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "<invalid URL>"
+	}
+
+	return u.Scheme + "://" + u.Host
+}
+
+// redactURLError replaces the URL of a *url.Error with its endpoint, the error is returned otherwise
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	return fmt.Errorf("%s %s: %w", urlErr.Op, endpoint(urlErr.URL), urlErr.Err)
+}
@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Types of the events
+const (
+	// EventCreated is sent when a new log of an ingress is created
+	EventCreated = "created"
+	// EventResolved is sent when an ingress with a logged error passes all checks again
+	EventResolved = "resolved"
+)
+
+// Event describes a finding of the auditor sent to the sinks
+type Event struct {
+	// Type is the type of the event, e.g. created
+	Type string `json:"type"`
+	// Timestamp is the time the finding was logged
+	Timestamp time.Time `json:"timestamp"`
+	// Namespace is the namespace of the ingress
+	Namespace string `json:"namespace"`
+	// Ingress is the name of the ingress
+	Ingress string `json:"ingress"`
+	// Host is the ingress host of the finding, empty if it is about the whole ingress
+	Host string `json:"host,omitempty"`
+	// Reason is the machine readable code of the finding
+	Reason string `json:"reason"`
+	// Category tells who should act on the finding, e.g. Network or Certificate
+	Category string `json:"category,omitempty"`
+	// Severity is the level of the log, e.g. Error
+	Severity string `json:"severity"`
+	// Message is the message of the log
+	Message string `json:"message"`
+	// LogName is the name of the IngressTLSLog recording the finding
+	LogName string `json:"logName,omitempty"`
+	// Fingerprint is the SHA-256 fingerprint of the certificate in the secret, if it was read
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// Sink delivers events to an external system
+type Sink interface {
+	// Name identifies the sink in the logs
+	Name() string
+	// Send delivers the event, retrying if the sink supports it
+	Send(ctx context.Context, event Event) error
+}
+
+// Dispatcher sends the events to all sinks without blocking the reconcile
+type Dispatcher struct {
+	sinks   []Sink
+	timeout time.Duration
+	log     logr.Logger
+}
+
+// NewDispatcher creates a Dispatcher, each send is cancelled after the timeout
+func NewDispatcher(log logr.Logger, timeout time.Duration, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{
+		sinks:   sinks,
+		timeout: timeout,
+		log:     log,
+	}
+}
+
+// orderedSink is a sink receiving the events in the order they are notified, like an audit stream
+// which must not write the resolution of a finding before its creation. The Dispatcher sends to it
+// synchronously, so its Send must not block on the network.
+type orderedSink interface {
+	Sink
+	ordered()
+}
+
+// Notify sends the event to every sink in the background, except to the ordered sinks written before it returns
+func (d *Dispatcher) Notify(event Event) {
+	for _, sink := range d.sinks {
+		if _, ok := sink.(orderedSink); ok {
+			d.send(sink, event)
+			continue
+		}
+		go d.send(sink, event)
+	}
+}
+
+// send sends the event to the sink and logs a failure
+func (d *Dispatcher) send(sink Sink, event Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+
+	if err := sink.Send(ctx, event); err != nil {
+		d.log.Error(err, "failed to send notification", "sink", sink.Name(),
+			"namespace", event.Namespace, "ingress", event.Ingress, "reason", event.Reason)
+	}
+}
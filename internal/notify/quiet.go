@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// maxQueuedEvents bounds the events a QuietSink holds back, the oldest are dropped first
+const maxQueuedEvents = 1000
+
+// quietFlushInterval is how often a running QuietSink checks for events whose quiet hours ended
+const quietFlushInterval = time.Minute
+
+// QuietSink holds back the events of a sink while the namespace of the event is in quiet hours,
+// and sends them once the quiet hours ended. Audit streams are not wrapped, they receive every event at once.
+type QuietSink struct {
+	sink  Sink
+	quiet func(namespace string, now time.Time) bool
+	log   logr.Logger
+
+	mu     sync.Mutex
+	queued []Event
+}
+
+// NewQuietSink creates a QuietSink, quiet reports whether the events of the namespace are held back at now
+func NewQuietSink(log logr.Logger, sink Sink, quiet func(namespace string, now time.Time) bool) *QuietSink {
+	return &QuietSink{
+		sink:  sink,
+		quiet: quiet,
+		log:   log,
+	}
+}
+
+// Name identifies the sink in the logs
+func (s *QuietSink) Name() string {
+	return s.sink.Name()
+}
+
+// Send queues the event in quiet hours, otherwise it sends the due queued events and then the event
+func (s *QuietSink) Send(ctx context.Context, event Event) error {
+	now := time.Now()
+	if s.quiet(event.Namespace, now) {
+		s.enqueue(event)
+		return nil
+	}
+
+	return errors.Join(s.Flush(ctx, now), s.sink.Send(ctx, event))
+}
+
+// Flush sends the queued events whose namespace is no longer in quiet hours at now, in the order they were queued.
+// An event failing to send is dropped, as if it was sent outside of quiet hours.
+func (s *QuietSink) Flush(ctx context.Context, now time.Time) error {
+	var errs []error
+	for _, event := range s.due(now) {
+		errs = append(errs, s.sink.Send(ctx, event))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Queued returns the number of events held back
+func (s *QuietSink) Queued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.queued)
+}
+
+// Run flushes the queued events every minute until the context is done, it is run by the manager
+func (s *QuietSink) Run(ctx context.Context) error {
+	ticker := time.NewTicker(quietFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if err := s.Flush(ctx, now); err != nil {
+				s.log.Error(err, "failed to send notifications held back in quiet hours", "sink", s.Name())
+			}
+		}
+	}
+}
+
+// enqueue holds back the event, dropping the oldest event if the queue is full
+func (s *QuietSink) enqueue(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queued) >= maxQueuedEvents {
+		s.log.Info("dropping a notification held back in quiet hours, the queue is full", "sink", s.Name(),
+			"namespace", s.queued[0].Namespace, "ingress", s.queued[0].Ingress, "reason", s.queued[0].Reason)
+		s.queued = s.queued[1:]
+	}
+	s.queued = append(s.queued, event)
+}
+
+// due removes and returns the queued events whose namespace is no longer in quiet hours at now
+func (s *QuietSink) due(now time.Time) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []Event
+	kept := s.queued[:0]
+	for _, event := range s.queued {
+		if s.quiet(event.Namespace, now) {
+			kept = append(kept, event)
+		} else {
+			due = append(due, event)
+		}
+	}
+	s.queued = kept
+
+	return due
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// recordingSink records the ingresses of the events it receives
+type recordingSink struct {
+	mu        sync.Mutex
+	ingresses []string
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Send(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ingresses = append(s.ingresses, event.Ingress)
+	return nil
+}
+
+var _ = Describe("QuietSink", func() {
+	ctx := context.Background()
+
+	It("should hold back the events of quiet namespaces until the quiet hours end", func() {
+		quietNamespaces := map[string]bool{"team-a": true}
+		var mu sync.Mutex
+		quiet := func(namespace string, _ time.Time) bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return quietNamespaces[namespace]
+		}
+		recorder := &recordingSink{}
+		sink := NewQuietSink(logr.Discard(), recorder, quiet)
+
+		Expect(sink.Send(ctx, Event{Namespace: "team-a", Ingress: "first"})).To(Succeed())
+		Expect(sink.Send(ctx, Event{Namespace: "team-a", Ingress: "second"})).To(Succeed())
+		Expect(sink.Send(ctx, Event{Namespace: "default", Ingress: "other"})).To(Succeed())
+		Expect(recorder.ingresses).To(Equal([]string{"other"}))
+		Expect(sink.Queued()).To(Equal(2))
+
+		Expect(sink.Flush(ctx, time.Now())).To(Succeed())
+		Expect(recorder.ingresses).To(Equal([]string{"other"}))
+
+		mu.Lock()
+		quietNamespaces["team-a"] = false
+		mu.Unlock()
+
+		Expect(sink.Send(ctx, Event{Namespace: "team-a", Ingress: "third"})).To(Succeed())
+		Expect(recorder.ingresses).To(Equal([]string{"other", "first", "second", "third"}))
+		Expect(sink.Queued()).To(BeZero())
+	})
+
+	It("should drop the oldest events once the queue is full", func() {
+		recorder := &recordingSink{}
+		sink := NewQuietSink(logr.Discard(), recorder, func(string, time.Time) bool { return true })
+
+		for range maxQueuedEvents + 1 {
+			Expect(sink.Send(ctx, Event{Namespace: "team-a"})).To(Succeed())
+		}
+		Expect(sink.Queued()).To(Equal(maxQueuedEvents))
+	})
+})
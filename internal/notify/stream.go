@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// auditRecord is one JSON line of the audit stream
+type auditRecord struct {
+	// Cluster is the name of the cluster the finding comes from
+	Cluster string `json:"cluster,omitempty"`
+	Event
+}
+
+// StreamSink writes every event as one JSON line, e.g. to stdout or a RotatingFile for SIEM ingestion
+type StreamSink struct {
+	mu      sync.Mutex
+	name    string
+	cluster string
+	encoder *json.Encoder
+}
+
+// NewStreamSink creates a StreamSink writing to w, stamping the lines with the cluster name
+func NewStreamSink(name string, w io.Writer, cluster string) *StreamSink {
+	return &StreamSink{
+		name:    name,
+		cluster: cluster,
+		encoder: json.NewEncoder(w),
+	}
+}
+
+// Name identifies the sink in the logs
+func (s *StreamSink) Name() string {
+	return "audit stream " + s.name
+}
+
+// ordered makes the Dispatcher write the events in order, the created and resolved events of an ingress must not be swapped
+func (s *StreamSink) ordered() {}
+
+// Send writes the event as one JSON line
+func (s *StreamSink) Send(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.encoder.Encode(auditRecord{Cluster: s.cluster, Event: event})
+}
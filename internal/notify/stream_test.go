@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamSink", func() {
+	event := Event{
+		Type:        EventCreated,
+		Timestamp:   time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+		Namespace:   "ns-1",
+		Ingress:     "ingress-1",
+		Host:        "https-example-1.foo.com",
+		Reason:      "CertificateExpired",
+		Severity:    "Error",
+		Message:     "TLS verification failed: the certificate is expired",
+		Fingerprint: "ab12",
+	}
+
+	It("should write one JSON line per event", func() {
+		out := &bytes.Buffer{}
+		sink := NewStreamSink("stdout", out, "cluster-1")
+
+		Expect(sink.Send(context.Background(), event)).To(Succeed())
+		Expect(sink.Send(context.Background(), event)).To(Succeed())
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+
+		record := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(lines[0]), &record)).To(Succeed())
+		Expect(record).To(HaveKeyWithValue("cluster", "cluster-1"))
+		Expect(record).To(HaveKeyWithValue("timestamp", "2025-12-12T00:00:00Z"))
+		Expect(record).To(HaveKeyWithValue("namespace", "ns-1"))
+		Expect(record).To(HaveKeyWithValue("ingress", "ingress-1"))
+		Expect(record).To(HaveKeyWithValue("host", "https-example-1.foo.com"))
+		Expect(record).To(HaveKeyWithValue("reason", "CertificateExpired"))
+		Expect(record).To(HaveKeyWithValue("severity", "Error"))
+		Expect(record).To(HaveKeyWithValue("fingerprint", "ab12"))
+	})
+
+	It("should be written by the dispatcher in the order of the events", func() {
+		out := &bytes.Buffer{}
+		dispatcher := NewDispatcher(GinkgoLogr, time.Second, NewStreamSink("stdout", out, ""))
+
+		for range 50 {
+			created, resolved := event, event
+			resolved.Type = EventResolved
+			dispatcher.Notify(created)
+			dispatcher.Notify(resolved)
+		}
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(100))
+		for i, line := range lines {
+			record := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+			if i%2 == 0 {
+				Expect(record).To(HaveKeyWithValue("type", EventCreated))
+			} else {
+				Expect(record).To(HaveKeyWithValue("type", EventResolved))
+			}
+		}
+	})
+
+	It("should rotate the file once it exceeds the size", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "audit.log")
+		file := &RotatingFile{Path: path, MaxBytes: 300, MaxBackups: 2}
+		defer func() { Expect(file.Close()).To(Succeed()) }()
+		sink := NewStreamSink(path, file, "")
+
+		for range 10 {
+			Expect(sink.Send(context.Background(), event)).To(Succeed())
+		}
+
+		Expect(path).To(BeAnExistingFile())
+		Expect(path + ".1").To(BeAnExistingFile())
+		Expect(path + ".2").To(BeAnExistingFile())
+		Expect(path + ".3").NotTo(BeAnExistingFile())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Size()).To(BeNumerically("<=", 300))
+	})
+})
@@ -0,0 +1,147 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"text/template"
+	"time"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the payload, formatted as sha256=<hex>
+const SignatureHeader = "X-Ingress-Audit-Signature"
+
+// Built-in formats of the webhook payload
+const (
+	FormatGeneric = "generic"
+	FormatSlack   = "slack"
+	FormatTeams   = "teams"
+)
+
+// builtinTemplates are the payloads of the built-in formats, all values are escaped with json
+var builtinTemplates = map[string]string{
+	FormatGeneric: `{{ json . }}`,
+	FormatSlack: `{"text": {{ printf "[%s] %s/%s %s: %s" .Severity .Namespace .Ingress .Reason .Message | json }},` +
+		`"blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": ` +
+		`{{ printf "*%s* in ` + "`%s/%s`" + `%s\n%s" .Reason .Namespace .Ingress (hostSuffix .Host) .Message | json }}}}]}`,
+	FormatTeams: `{"@type": "MessageCard", "@context": "https://schema.org/extensions",` +
+		`"themeColor": {{ themeColor .Severity | json }},` +
+		`"summary": {{ printf "%s %s/%s" .Reason .Namespace .Ingress | json }},` +
+		`"sections": [{"activityTitle": {{ printf "%s in %s/%s" .Reason .Namespace .Ingress | json }},` +
+		`"facts": [{"name": "Severity", "value": {{ json .Severity }}},` +
+		`{"name": "Host", "value": {{ json .Host }}},` +
+		`{"name": "Category", "value": {{ json .Category }}}],` +
+		`"text": {{ json .Message }}}]}`,
+}
+
+// templateFuncs are available in the payload templates
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"hostSuffix": func(host string) string {
+		if host == "" {
+			return ""
+		}
+		return " for " + host
+	},
+	"themeColor": func(severity string) string {
+		switch severity {
+		case "Error":
+			return "D70000"
+		case "Warn":
+			return "FFA500"
+		default:
+			return "0078D7"
+		}
+	},
+}
+
+// ParseTemplate returns the payload template of a built-in format, or parses the template file if it is set
+func ParseTemplate(format, templateFile string) (*template.Template, error) {
+	if templateFile != "" {
+		text, err := os.ReadFile(templateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook template: %w", err)
+		}
+		return template.New(templateFile).Funcs(templateFuncs).Parse(string(text))
+	}
+
+	text, ok := builtinTemplates[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown webhook format %q", format)
+	}
+
+	return template.New(format).Funcs(templateFuncs).Parse(text)
+}
+
+// WebhookOptions configures a WebhookSink
+type WebhookOptions struct {
+	// URL is the endpoint the payload is POSTed to
+	URL string
+	// Template renders the event into the payload
+	Template *template.Template
+	// Secret signs the payload in the SignatureHeader if it is set
+	Secret []byte
+	// MaxRetries is the number of retries after the first failed attempt
+	MaxRetries int
+	// Backoff is the wait before the first retry, doubled for every further retry
+	Backoff time.Duration
+	// Client sends the requests, http.DefaultClient is used if it is nil
+	Client *http.Client
+}
+
+// WebhookSink POSTs a templated JSON payload to an HTTP endpoint
+type WebhookSink struct {
+	opts WebhookOptions
+}
+
+// NewWebhookSink creates a WebhookSink
+func NewWebhookSink(opts WebhookOptions) *WebhookSink {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	return &WebhookSink{opts: opts}
+}
+
+// Name identifies the sink in the logs
+func (w *WebhookSink) Name() string {
+	return "webhook " + endpoint(w.opts.URL)
+}
+
+// Send renders the event and POSTs it, retrying with backoff on network errors, 429 and 5xx
+func (w *WebhookSink) Send(ctx context.Context, event Event) error {
+	var payload bytes.Buffer
+	if err := w.opts.Template.Execute(&payload, event); err != nil {
+		return fmt.Errorf("failed to render webhook payload: %w", err)
+	}
+
+	return sendWithRetry(ctx, w.opts.Client, w.opts.MaxRetries, w.opts.Backoff, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.URL, bytes.NewReader(payload.Bytes()))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		if len(w.opts.Secret) != 0 {
+			req.Header.Set(SignatureHeader, Sign(w.opts.Secret, payload.Bytes()))
+		}
+
+		return req, nil
+	})
+}
+
+// Sign returns the HMAC-SHA256 of the payload in the format of the SignatureHeader
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,141 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookSink", func() {
+	var (
+		mu       sync.Mutex
+		requests []*http.Request
+		bodies   [][]byte
+		statuses []int
+		server   *httptest.Server
+		event    Event
+	)
+
+	BeforeEach(func() {
+		requests, bodies, statuses = nil, nil, nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, r)
+			bodies = append(bodies, body)
+
+			status := http.StatusOK
+			if len(statuses) != 0 {
+				status, statuses = statuses[0], statuses[1:]
+			}
+			w.WriteHeader(status)
+		}))
+
+		event = Event{
+			Type:      EventCreated,
+			Timestamp: time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+			Namespace: "ns-1",
+			Ingress:   "ingress-1",
+			Host:      "https-example-1.foo.com",
+			Reason:    "HostnameMismatch",
+			Category:  "Certificate",
+			Severity:  "Error",
+			Message:   `TLS verification failed: the certificate does not match the "host"`,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newSink := func(format string, secret []byte) *WebhookSink {
+		tmpl, err := ParseTemplate(format, "")
+		Expect(err).NotTo(HaveOccurred())
+		return NewWebhookSink(WebhookOptions{
+			URL:        server.URL,
+			Template:   tmpl,
+			Secret:     secret,
+			MaxRetries: 2,
+			Backoff:    time.Millisecond,
+		})
+	}
+
+	It("should POST the generic payload with its signature", func() {
+		Expect(newSink(FormatGeneric, []byte("secret")).Send(context.Background(), event)).To(Succeed())
+
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(requests[0].Header.Get(SignatureHeader)).To(Equal(Sign([]byte("secret"), bodies[0])))
+
+		received := Event{}
+		Expect(json.Unmarshal(bodies[0], &received)).To(Succeed())
+		Expect(received).To(Equal(event))
+	})
+
+	DescribeTable("should render valid JSON for the built-in formats",
+		func(format string, key string) {
+			Expect(newSink(format, nil).Send(context.Background(), event)).To(Succeed())
+
+			Expect(requests[0].Header.Get(SignatureHeader)).To(BeEmpty())
+			payload := map[string]interface{}{}
+			Expect(json.Unmarshal(bodies[0], &payload)).To(Succeed())
+			Expect(payload).To(HaveKey(key))
+		},
+		Entry("slack", FormatSlack, "text"),
+		Entry("teams", FormatTeams, "sections"),
+	)
+
+	It("should retry with backoff on server errors", func() {
+		statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+
+		Expect(newSink(FormatGeneric, nil).Send(context.Background(), event)).To(Succeed())
+		Expect(requests).To(HaveLen(3))
+	})
+
+	It("should give up after the retries", func() {
+		statuses = []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError}
+
+		Expect(newSink(FormatGeneric, nil).Send(context.Background(), event)).NotTo(Succeed())
+		Expect(requests).To(HaveLen(3))
+	})
+
+	It("should not retry on client errors", func() {
+		statuses = []int{http.StatusBadRequest}
+
+		Expect(newSink(FormatGeneric, nil).Send(context.Background(), event)).NotTo(Succeed())
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("should keep the token of the URL out of the name and errors of the sink", func() {
+		tmpl, err := ParseTemplate(FormatSlack, "")
+		Expect(err).NotTo(HaveOccurred())
+		sink := NewWebhookSink(WebhookOptions{URL: server.URL + "/services/T000/B000/token", Template: tmpl})
+		Expect(sink.Name()).To(Equal("webhook " + server.URL))
+
+		statuses = []int{http.StatusBadRequest}
+		err = sink.Send(context.Background(), event)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).NotTo(ContainSubstring("token"))
+
+		// A closed server fails the request with a *url.Error holding the URL
+		server.Close()
+		err = sink.Send(context.Background(), event)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(server.URL))
+		Expect(err.Error()).NotTo(ContainSubstring("token"))
+	})
+
+	It("should reject unknown formats", func() {
+		_, err := ParseTemplate("unknown", "")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,147 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultMaxRenotifyInterval caps the re-notification backoff if MaxRenotifyInterval is not set
+const defaultMaxRenotifyInterval = 24 * time.Hour
+
+// Escalation configures how the severity and the repetition of a persisting finding evolve
+type Escalation struct {
+	// EscalateAfterIntervals logs new findings as Warn and escalates them to Error once they persist
+	// for this many intervals. If it is zero, all findings are logged as Error
+	EscalateAfterIntervals int `json:"escalateAfterIntervals,omitempty"`
+	// RenotifyBackoff multiplies the wait before a persisting finding is logged again after each repetition.
+	// If it is zero or one, the finding is logged again after every interval
+	RenotifyBackoff float64 `json:"renotifyBackoff,omitempty"`
+	// MaxRenotifyInterval caps the wait between two repetitions of a finding, in default is 24h
+	MaxRenotifyInterval *metav1.Duration `json:"maxRenotifyInterval,omitempty"`
+	// QuietHours are the windows in which the notifications of findings are held back until the window ends
+	QuietHours []QuietHours `json:"quietHours,omitempty"`
+}
+
+// QuietHours is a daily window in which the findings of the matching namespaces are not notified
+type QuietHours struct {
+	// Namespaces are the namespaces the window applies to, they may be shell patterns like team-*
+	Namespaces []string `json:"namespaces"`
+	// Start is the start of the window as HH:MM
+	Start string `json:"start"`
+	// End is the end of the window as HH:MM, the window spans midnight if it is before Start
+	End string `json:"end"`
+	// TimeZone is the IANA time zone of Start and End, in default is UTC
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Severity returns the severity of a finding first seen age ago
+func (e *Escalation) Severity(age, interval time.Duration) string {
+	if e.EscalateAfterIntervals <= 0 || age >= time.Duration(e.EscalateAfterIntervals)*interval {
+		return "Error"
+	}
+
+	return "Warn"
+}
+
+// RenotifyInterval returns the wait before a finding logged again repeats times is logged once more
+func (e *Escalation) RenotifyInterval(repeats int, interval time.Duration) time.Duration {
+	maxInterval := defaultMaxRenotifyInterval
+	if e.MaxRenotifyInterval != nil {
+		maxInterval = e.MaxRenotifyInterval.Duration
+	}
+
+	wait := interval
+	if e.RenotifyBackoff > 1 {
+		for range repeats {
+			if wait >= maxInterval {
+				break
+			}
+			wait = time.Duration(float64(wait) * e.RenotifyBackoff)
+		}
+	}
+
+	return min(wait, max(maxInterval, interval))
+}
+
+// Quiet reports whether the findings of the namespace are in quiet hours at now
+func (e *Escalation) Quiet(namespace string, now time.Time) bool {
+	for _, q := range e.QuietHours {
+		if q.matches(namespace) && q.active(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches reports whether the window applies to the namespace
+func (q QuietHours) matches(namespace string) bool {
+	for _, pattern := range q.Namespaces {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// active reports whether now is within the window, Start and End are validated by Parse
+func (q QuietHours) active(now time.Time) bool {
+	location, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return false
+	}
+
+	start, _ := parseClock(q.Start)
+	end, _ := parseClock(q.End)
+
+	local := now.In(location)
+	clock := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+
+	if start <= end {
+		return clock >= start && clock < end
+	}
+
+	// The window spans midnight
+	return clock >= start || clock < end
+}
+
+// validate checks the window
+func (q QuietHours) validate() error {
+	if len(q.Namespaces) == 0 {
+		return fmt.Errorf("namespaces is required")
+	}
+
+	for _, pattern := range q.Namespaces {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid namespace pattern %q: %w", pattern, err)
+		}
+	}
+
+	if _, err := parseClock(q.Start); err != nil {
+		return fmt.Errorf("invalid start: %w", err)
+	}
+
+	if _, err := parseClock(q.End); err != nil {
+		return fmt.Errorf("invalid end: %w", err)
+	}
+
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		return fmt.Errorf("invalid timeZone: %w", err)
+	}
+
+	return nil
+}
+
+// parseClock parses HH:MM into the duration since midnight
+func parseClock(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,66 @@
+package policy
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Escalation", func() {
+	const interval = time.Hour
+
+	It("should log all findings as Error without escalation", func() {
+		e := &Escalation{}
+		Expect(e.Severity(0, interval)).To(Equal("Error"))
+		Expect(e.RenotifyInterval(5, interval)).To(Equal(interval))
+	})
+
+	It("should escalate Warn to Error after the intervals", func() {
+		p, err := Parse([]byte("escalation:\n  escalateAfterIntervals: 3\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(p.Escalation.Severity(0, interval)).To(Equal("Warn"))
+		Expect(p.Escalation.Severity(2*interval, interval)).To(Equal("Warn"))
+		Expect(p.Escalation.Severity(3*interval, interval)).To(Equal("Error"))
+	})
+
+	It("should back off the re-notification up to the maximum", func() {
+		p, err := Parse([]byte("escalation:\n  renotifyBackoff: 2\n  maxRenotifyInterval: 6h\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(p.Escalation.RenotifyInterval(0, interval)).To(Equal(interval))
+		Expect(p.Escalation.RenotifyInterval(1, interval)).To(Equal(2 * interval))
+		Expect(p.Escalation.RenotifyInterval(2, interval)).To(Equal(4 * interval))
+		Expect(p.Escalation.RenotifyInterval(3, interval)).To(Equal(6 * interval))
+		Expect(p.Escalation.RenotifyInterval(1000, interval)).To(Equal(6 * interval))
+	})
+
+	It("should hold back notifications in the quiet hours of the namespace", func() {
+		p, err := Parse([]byte(`
+escalation:
+  quietHours:
+  - namespaces: ["team-*"]
+    start: "22:00"
+    end: "07:00"
+    timeZone: Europe/Helsinki
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		// 23:30 and 12:00 in Helsinki
+		night := time.Date(2025, 12, 12, 21, 30, 0, 0, time.UTC)
+		noon := time.Date(2025, 12, 12, 10, 0, 0, 0, time.UTC)
+
+		Expect(p.Escalation.Quiet("team-a", night)).To(BeTrue())
+		Expect(p.Escalation.Quiet("team-a", noon)).To(BeFalse())
+		Expect(p.Escalation.Quiet("default", night)).To(BeFalse())
+	})
+
+	It("should reject invalid quiet hours", func() {
+		_, err := Parse([]byte("escalation:\n  quietHours:\n  - namespaces: [a]\n    start: \"25:00\"\n    end: \"07:00\"\n"))
+		Expect(err).To(MatchError(ContainSubstring("escalation.quietHours[0]: invalid start")))
+
+		_, err = Parse([]byte("escalation:\n  quietHours:\n  - start: \"22:00\"\n    end: \"07:00\"\n"))
+		Expect(err).To(MatchError(ContainSubstring("namespaces is required")))
+	})
+})
@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Policy configures the audit, it is loaded from the policy file
+type Policy struct {
+	// Notifications configures the sinks findings are sent to, in addition to the flags
+	Notifications Notifications `json:"notifications,omitempty"`
+	// Escalation configures the severity and repetition of persisting findings
+	Escalation Escalation `json:"escalation,omitempty"`
+	// Rules are custom checks run after the built-in checkers
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// Notifications configures the sinks findings are sent to
+type Notifications struct {
+	// CloudEvents are the brokers receiving the findings as CloudEvents
+	CloudEvents []CloudEventsTarget `json:"cloudEvents,omitempty"`
+}
+
+// CloudEventsTarget is a broker receiving the findings as CloudEvents
+type CloudEventsTarget struct {
+	// URL is the endpoint of the broker
+	URL string `json:"url"`
+	// Mode is structured or binary, in default is structured
+	Mode string `json:"mode,omitempty"`
+}
+
+// Load reads and validates the policy file
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse parses and validates the YAML or JSON policy
+func Parse(data []byte) (*Policy, error) {
+	p := &Policy{}
+	if err := yaml.UnmarshalStrict(data, p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	for i, target := range p.Notifications.CloudEvents {
+		if target.URL == "" {
+			return nil, fmt.Errorf("notifications.cloudEvents[%d].url is required", i)
+		}
+	}
+
+	if p.Escalation.EscalateAfterIntervals < 0 {
+		return nil, fmt.Errorf("escalation.escalateAfterIntervals must not be negative")
+	}
+
+	if p.Escalation.RenotifyBackoff < 0 {
+		return nil, fmt.Errorf("escalation.renotifyBackoff must not be negative")
+	}
+
+	for i, q := range p.Escalation.QuietHours {
+		if err := q.validate(); err != nil {
+			return nil, fmt.Errorf("escalation.quietHours[%d]: %w", i, err)
+		}
+	}
+
+	if err := compileRules(p.Rules); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
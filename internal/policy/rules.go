@@ -0,0 +1,158 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// Variables available in the expressions of the rules
+const (
+	// IngressVariable is the ingress as in its YAML, e.g. ingress.spec.ingressClassName
+	IngressVariable = "ingress"
+	// SecretsVariable are the metadata of the secrets referenced by the TLS blocks, without their data
+	SecretsVariable = "secrets"
+	// CertificatesVariable are the parsed certificates of the secrets
+	CertificatesVariable = "certificates"
+	// NowVariable is the time of the evaluation
+	NowVariable = "now"
+)
+
+// maxRuleMessageLength is the maximum length of the message of an IngressTLSLog
+const maxRuleMessageLength = 120
+
+// maxRuleCost bounds the runtime cost of an evaluation, as the per-call limit of the Kubernetes CEL validation rules
+const maxRuleCost = 1000000
+
+// ruleInterruptCheckFrequency is the number of comprehension iterations between the checks of the context of an evaluation
+const ruleInterruptCheckFrequency = 100
+
+// reasonPattern matches the CamelCase reason codes of the IngressTLSLogs
+var reasonPattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]{0,62}$`)
+
+// Rule is a custom check of the ingresses written as a CEL expression
+type Rule struct {
+	// Name identifies the rule, it is the name of its checker
+	Name string `json:"name"`
+	// Expression must evaluate to true for the ingress to pass, see the variables for its inputs
+	Expression string `json:"expression"`
+	// Reason is the CamelCase code recorded in the IngressTLSLog, it must not be used by another reason
+	Reason string `json:"reason"`
+	// Severity is Error, Warn or Info, in default is Error.
+	// Only Error findings are escalated
+	Severity string `json:"severity,omitempty"`
+	// Message is the message of the IngressTLSLog, in default it names the rule
+	Message string `json:"message,omitempty"`
+
+	// program is the compiled Expression
+	program cel.Program
+}
+
+// ruleEnv returns the CEL environment the expressions are compiled in
+func ruleEnv() (*cel.Env, error) {
+	object := cel.MapType(cel.StringType, cel.DynType)
+	return cel.NewEnv(
+		cel.Variable(IngressVariable, object),
+		cel.Variable(SecretsVariable, cel.ListType(object)),
+		cel.Variable(CertificatesVariable, cel.ListType(object)),
+		cel.Variable(NowVariable, cel.TimestampType),
+		ext.Strings(),
+	)
+}
+
+// compile validates the rule and compiles its expression
+func (r *Rule) compile(env *cel.Env) error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if !reasonPattern.MatchString(r.Reason) {
+		return fmt.Errorf("reason %q must be CamelCase and at most 63 characters", r.Reason)
+	}
+
+	switch r.Severity {
+	case "":
+		r.Severity = "Error"
+	case "Error", "Warn", "Info":
+	default:
+		return fmt.Errorf("severity %q must be Error, Warn or Info", r.Severity)
+	}
+
+	if r.Message == "" {
+		r.Message = fmt.Sprintf("the ingress violates the rule %s", r.Name)
+	}
+	if len(r.Message) > maxRuleMessageLength {
+		return fmt.Errorf("message must be at most %d characters", maxRuleMessageLength)
+	}
+
+	ast, issues := env.Compile(r.Expression)
+	if issues.Err() != nil {
+		return fmt.Errorf("invalid expression: %w", issues.Err())
+	}
+	if !ast.OutputType().IsExactType(cel.BoolType) {
+		return fmt.Errorf("expression must evaluate to bool, not %s", ast.OutputType())
+	}
+
+	program, err := env.Program(ast, cel.CostLimit(maxRuleCost), cel.InterruptCheckFrequency(ruleInterruptCheckFrequency))
+	if err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+
+	r.program = program
+	return nil
+}
+
+// Eval evaluates the expression with the variables, it reports whether the ingress passes the rule.
+// The evaluation fails once its cost exceeds the limit or the context is done.
+func (r *Rule) Eval(ctx context.Context, vars map[string]any) (bool, error) {
+	if r.program == nil {
+		return false, fmt.Errorf("rule %s is not compiled", r.Name)
+	}
+
+	out, _, err := r.program.ContextEval(ctx, vars)
+	if err != nil {
+		return false, err
+	}
+
+	passed, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluated to %v instead of bool", out.Value())
+	}
+
+	return passed, nil
+}
+
+// compileRules validates the rules and compiles their expressions
+func compileRules(rules []Rule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	env, err := ruleEnv()
+	if err != nil {
+		return err
+	}
+
+	names := map[string]bool{}
+	reasons := map[string]bool{}
+	for i := range rules {
+		rule := &rules[i]
+		if err := rule.compile(env); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+
+		if names[rule.Name] {
+			return fmt.Errorf("rules[%d]: name %q is already used", i, rule.Name)
+		}
+		if reasons[rule.Reason] {
+			return fmt.Errorf("rules[%d]: reason %q is already used", i, rule.Reason)
+		}
+		names[rule.Name] = true
+		reasons[rule.Reason] = true
+	}
+
+	return nil
+}
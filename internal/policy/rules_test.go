@@ -0,0 +1,109 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rules", func() {
+	ctx := context.Background()
+
+	const rules = `
+rules:
+- name: prod-external-class
+  expression: >-
+    !ingress.metadata.namespace.startsWith("prod-") ||
+    (has(ingress.spec.ingressClassName) && ingress.spec.ingressClassName == "external")
+  reason: ProdIngressClass
+  severity: Warn
+  message: ingresses in prod-* namespaces must use the IngressClass external
+- name: certificate-lifetime
+  expression: certificates.all(c, c.notAfter - now > duration("720h"))
+  reason: CertificateLifetime
+`
+
+	It("should compile the rules and default their severity and message", func() {
+		p, err := Parse([]byte(rules))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.Rules).To(HaveLen(2))
+		Expect(p.Rules[0].Severity).To(Equal("Warn"))
+		Expect(p.Rules[1].Severity).To(Equal("Error"))
+		Expect(p.Rules[1].Message).To(Equal("the ingress violates the rule certificate-lifetime"))
+	})
+
+	It("should evaluate the rules on the variables", func() {
+		p, err := Parse([]byte(rules))
+		Expect(err).NotTo(HaveOccurred())
+
+		vars := func(namespace, class string, notAfter time.Time) map[string]any {
+			spec := map[string]any{}
+			if class != "" {
+				spec["ingressClassName"] = class
+			}
+			return map[string]any{
+				IngressVariable:      map[string]any{"metadata": map[string]any{"namespace": namespace}, "spec": spec},
+				SecretsVariable:      []map[string]any{},
+				CertificatesVariable: []map[string]any{{"notAfter": notAfter}},
+				NowVariable:          time.Now(),
+			}
+		}
+
+		longLived := time.Now().Add(365 * 24 * time.Hour)
+		Expect(p.Rules[0].Eval(ctx, vars("prod-shop", "external", longLived))).To(BeTrue())
+		Expect(p.Rules[0].Eval(ctx, vars("prod-shop", "internal", longLived))).To(BeFalse())
+		Expect(p.Rules[0].Eval(ctx, vars("prod-shop", "", longLived))).To(BeFalse())
+		Expect(p.Rules[0].Eval(ctx, vars("dev", "", longLived))).To(BeTrue())
+
+		Expect(p.Rules[1].Eval(ctx, vars("dev", "", longLived))).To(BeTrue())
+		Expect(p.Rules[1].Eval(ctx, vars("dev", "", time.Now().Add(24*time.Hour)))).To(BeFalse())
+	})
+
+	It("should stop the evaluation once its cost exceeds the limit or the context is done", func() {
+		p, err := Parse([]byte(`
+rules:
+- name: quadratic
+  expression: secrets.all(a, secrets.all(b, a.name == b.name || a.name != b.name))
+  reason: Quadratic
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		secrets := make([]map[string]any, 2000)
+		for i := range secrets {
+			secrets[i] = map[string]any{"name": fmt.Sprintf("secret-%d", i)}
+		}
+		vars := map[string]any{
+			IngressVariable:      map[string]any{},
+			SecretsVariable:      secrets,
+			CertificatesVariable: []map[string]any{},
+			NowVariable:          time.Now(),
+		}
+
+		_, err = p.Rules[0].Eval(ctx, vars)
+		Expect(err).To(MatchError(ContainSubstring("cost limit exceeded")))
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		vars[SecretsVariable] = secrets[:10]
+		_, err = p.Rules[0].Eval(canceled, vars)
+		Expect(err).To(MatchError(ContainSubstring("interrupted")))
+	})
+
+	DescribeTable("should reject invalid rules",
+		func(rule, message string) {
+			_, err := Parse([]byte("rules:\n" + rule))
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("without name", "- expression: 'true'\n  reason: Custom\n", "name is required"),
+		Entry("with an invalid reason", "- name: a\n  expression: 'true'\n  reason: custom-reason\n", "must be CamelCase"),
+		Entry("with an invalid severity", "- name: a\n  expression: 'true'\n  reason: Custom\n  severity: Fatal\n", "must be Error, Warn or Info"),
+		Entry("with a syntax error", "- name: a\n  expression: 'ingress.metadata.'\n  reason: Custom\n", "invalid expression"),
+		Entry("with an unknown variable", "- name: a\n  expression: 'service.name == \"a\"'\n  reason: Custom\n", "undeclared reference"),
+		Entry("not evaluating to bool", "- name: a\n  expression: 'size(secrets)'\n  reason: Custom\n", "must evaluate to bool"),
+		Entry("with a duplicate reason",
+			"- name: a\n  expression: 'true'\n  reason: Custom\n- name: b\n  expression: 'true'\n  reason: Custom\n", "already used"),
+	)
+})
@@ -0,0 +1,278 @@
+package prober
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+// Options configures the shared Prober
+type Options struct {
+	// Workers is the maximum number of probes running at the same time
+	Workers int
+	// RatePerSecond is the number of probes allowed per second towards the same destination
+	RatePerSecond float64
+	// Burst is the number of probes allowed at once towards the same destination
+	Burst int
+	// CacheTTL is how long a probe result is reused, results are not cached when it is zero
+	CacheTTL time.Duration
+}
+
+type cacheEntry struct {
+	err       error
+	expiresAt time.Time
+}
+
+// limiterEntry is the rate limiter of a destination with its last use
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// flight is the context of the probes shared by the callers waiting for them.
+// It is cancelled once all callers have left, so a cancelled caller does not fail the others.
+type flight struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// minLimiterIdleTTL is the minimum time an unused limiter is kept
+const minLimiterIdleTTL = time.Minute
+
+// errFlightCancelled means all callers of a probe left before it ran, a caller joining it late probes again
+var errFlightCancelled = errors.New("the probe was cancelled by its callers")
+
+// Prober runs TLS probes for all reconciles on a bounded worker pool.
+// Probes towards the same destination are rate limited, and results are cached
+// so that ingresses sharing a host do not probe it twice.
+type Prober struct {
+	opts Options
+
+	// workers holds one token per running probe
+	workers chan struct{}
+	group   singleflight.Group
+
+	mu       sync.Mutex
+	limiters map[string]*limiterEntry
+	cache    map[string]cacheEntry
+	flights  map[string]*flight
+	// limiterIdleTTL is how long an unused limiter is kept, a limiter unused for longer than
+	// Burst/RatePerSecond is full again and is replaced by a new one without changing the rate
+	limiterIdleTTL time.Duration
+}
+
+// New creates a Prober with the given options
+func New(opts Options) *Prober {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = 1
+	}
+
+	limiterIdleTTL := minLimiterIdleTTL
+	if opts.RatePerSecond > 0 {
+		limiterIdleTTL = max(limiterIdleTTL, time.Duration(float64(opts.Burst)/opts.RatePerSecond*float64(time.Second)))
+	}
+
+	return &Prober{
+		opts:           opts,
+		workers:        make(chan struct{}, opts.Workers),
+		limiters:       make(map[string]*limiterEntry),
+		cache:          make(map[string]cacheEntry),
+		flights:        make(map[string]*flight),
+		limiterIdleTTL: limiterIdleTTL,
+	}
+}
+
+// Probe verifies the TLS of the target with the PEM crt and key.
+// It blocks until a worker is free and the destination rate limit allows the probe,
+// or returns the cached result of an identical probe.
+func (p *Prober) Probe(ctx context.Context, log logr.Logger, crtPEM, keyPEM []byte, target utils.ProbeTarget) error {
+	key := cacheKey(crtPEM, target)
+
+	if err, ok := p.cached(key); ok {
+		return err
+	}
+
+	for {
+		err, ok := p.probe(ctx, log, key, crtPEM, keyPEM, target)
+		if ok || ctx.Err() != nil || !errors.Is(err, errFlightCancelled) {
+			return err
+		}
+		// The probe was cancelled by the callers who left before this one joined it
+	}
+}
+
+// probe joins or starts the probe of the key and waits for it until ctx is done.
+// The boolean is true if the probe ran, its error is then the result of the probe.
+func (p *Prober) probe(
+	ctx context.Context,
+	log logr.Logger,
+	key string,
+	crtPEM, keyPEM []byte,
+	target utils.ProbeTarget,
+) (error, bool) {
+	flightCtx := p.join(ctx, key)
+	defer p.leave(key)
+
+	// Identical probes running at the same time share one connection
+	ch := p.group.DoChan(key, func() (interface{}, error) {
+		if err, ok := p.cached(key); ok {
+			return probeResult{err: err}, nil
+		}
+
+		if err := p.limiter(target.DialAddress()).Wait(flightCtx); err != nil {
+			return nil, flightErr(flightCtx, err)
+		}
+
+		select {
+		case p.workers <- struct{}{}:
+		case <-flightCtx.Done():
+			return nil, errFlightCancelled
+		}
+		defer func() { <-p.workers }()
+
+		probeErr := utils.CheckTLS(log, crtPEM, keyPEM, target)
+		p.store(key, probeErr)
+
+		return probeResult{err: probeErr}, nil
+	})
+
+	select {
+	case result := <-ch:
+		if result.Err != nil {
+			// The probe did not run, e.g. all callers left
+			return result.Err, false
+		}
+		return result.Val.(probeResult).err, true
+	case <-ctx.Done():
+		return ctx.Err(), false
+	}
+}
+
+// join registers the caller of the probe of the key and returns the context of the probe
+func (p *Prober) join(ctx context.Context, key string) context.Context {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, ok := p.flights[key]
+	if !ok {
+		// The probe keeps the values of the context of its first caller but not its cancellation
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{ctx: flightCtx, cancel: cancel}
+		p.flights[key] = f
+	}
+	f.waiters++
+
+	return f.ctx
+}
+
+// leave unregisters the caller of the probe of the key and cancels the probe once no caller waits for it
+func (p *Prober) leave(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f := p.flights[key]
+	f.waiters--
+	if f.waiters == 0 {
+		f.cancel()
+		delete(p.flights, key)
+	}
+}
+
+// flightErr returns errFlightCancelled if the probe failed because all its callers left
+func flightErr(flightCtx context.Context, err error) error {
+	if flightCtx.Err() != nil {
+		return errFlightCancelled
+	}
+
+	return err
+}
+
+// probeResult wraps the probe error so that it is not mistaken for a failure to run the probe
+type probeResult struct {
+	err error
+}
+
+// limiter returns the rate limiter of the destination and drops the limiters unused for limiterIdleTTL
+func (p *Prober) limiter(destination string) *rate.Limiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for d, entry := range p.limiters {
+		if now.Sub(entry.lastUsed) > p.limiterIdleTTL {
+			delete(p.limiters, d)
+		}
+	}
+
+	entry, ok := p.limiters[destination]
+	if !ok {
+		limit := rate.Inf
+		if p.opts.RatePerSecond > 0 {
+			limit = rate.Limit(p.opts.RatePerSecond)
+		}
+		entry = &limiterEntry{limiter: rate.NewLimiter(limit, p.opts.Burst)}
+		p.limiters[destination] = entry
+	}
+	entry.lastUsed = now
+
+	return entry.limiter
+}
+
+// cached returns the result of the probe if it has not expired
+func (p *Prober) cached(key string) (error, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.cache[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		delete(p.cache, key)
+		return nil, false
+	}
+
+	return entry.err, true
+}
+
+// store records the result of the probe and drops expired results
+func (p *Prober) store(key string, err error) {
+	if p.opts.CacheTTL <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range p.cache {
+		if now.After(entry.expiresAt) {
+			delete(p.cache, k)
+		}
+	}
+
+	p.cache[key] = cacheEntry{
+		err:       err,
+		expiresAt: now.Add(p.opts.CacheTTL),
+	}
+}
+
+// cacheKey identifies a probe by its SNI host, dialed address and expected certificate
+func cacheKey(crtPEM []byte, target utils.ProbeTarget) string {
+	sum := sha256.Sum256(crtPEM)
+	return target.Host + "|" + target.DialAddress() + "|" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,149 @@
+package prober
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+var _ = Describe("Prober", func() {
+	var (
+		server      *httptest.Server
+		connections atomic.Int32
+		crtPEM      []byte
+		target      utils.ProbeTarget
+	)
+
+	BeforeEach(func() {
+		connections.Store(0)
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+		server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				connections.Add(1)
+			}
+		}
+		server.StartTLS()
+
+		crtPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		portNumber, err := strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+
+		// The httptest certificate is issued for example.com, which is sent as SNI
+		target = utils.ProbeTarget{Host: "example.com", Address: host, Port: portNumber}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should verify the TLS of the dialed address with the host as SNI", func() {
+		p := New(Options{Workers: 1})
+		Expect(p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)).To(Succeed())
+
+		target.Host = "other.example.org"
+		err := p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)
+		Expect(err).To(MatchError(utils.ErrHostnameMismatch))
+	})
+
+	It("should classify the failures of the probe", func() {
+		p := New(Options{Workers: 1})
+
+		By("serving a certificate that is not the expected one")
+		otherPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not-a-certificate")})
+		err := p.Probe(context.Background(), logr.Discard(), otherPEM, nil, target)
+		Expect(err).To(MatchError(utils.ErrUnknownAuthority))
+
+		By("dialing a closed port")
+		server.Close()
+		err = p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)
+		Expect(err).To(MatchError(utils.ErrConnectionRefused))
+
+		By("dialing a host that does not resolve")
+		target.Address = "does-not-exist.invalid"
+		err = p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)
+		Expect(err).To(MatchError(utils.ErrDNSResolution))
+	})
+
+	It("should reuse the cached result for the same host", func() {
+		p := New(Options{Workers: 2, CacheTTL: time.Minute})
+
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)).To(Succeed())
+			}()
+		}
+		wg.Wait()
+
+		Expect(connections.Load()).To(Equal(int32(1)))
+	})
+
+	It("should probe again once the cached result expires", func() {
+		p := New(Options{Workers: 1, CacheTTL: 10 * time.Millisecond})
+
+		Expect(p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)).To(Succeed())
+		time.Sleep(20 * time.Millisecond)
+		Expect(p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)).To(Succeed())
+
+		Expect(connections.Load()).To(Equal(int32(2)))
+	})
+
+	It("should stop waiting for the rate limit when the context is cancelled", func() {
+		p := New(Options{Workers: 1, RatePerSecond: 0.001, Burst: 1})
+		Expect(p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		Expect(p.Probe(ctx, logr.Discard(), crtPEM, nil, target)).NotTo(Succeed())
+	})
+
+	It("should keep probing for the other callers when the first caller is cancelled", func() {
+		p := New(Options{Workers: 1, RatePerSecond: 20, Burst: 1})
+		Expect(p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)).To(Succeed())
+
+		// The next probe waits for the rate limit, the first caller gives up before
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		first := make(chan error, 1)
+		go func() { first <- p.Probe(ctx, logr.Discard(), crtPEM, nil, target) }()
+		time.Sleep(2 * time.Millisecond)
+
+		Expect(p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)).To(Succeed())
+		Expect(<-first).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should drop the limiters of idle destinations", func() {
+		p := New(Options{Workers: 1, RatePerSecond: 1000, Burst: 1})
+		p.limiterIdleTTL = 10 * time.Millisecond
+
+		Expect(p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)).To(Succeed())
+		time.Sleep(20 * time.Millisecond)
+
+		target.Address = "localhost"
+		Expect(p.Probe(context.Background(), logr.Discard(), crtPEM, nil, target)).To(Succeed())
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		Expect(p.limiters).To(HaveLen(1))
+		Expect(p.limiters).To(HaveKey(target.DialAddress()))
+		Expect(p.flights).To(BeEmpty())
+	})
+})
@@ -0,0 +1,563 @@
+package report
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/utils"
+)
+
+// Formats of the compliance report
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
+// Compliance is the evidence that the ingresses use TLS, built from the ingresses and their logs
+type Compliance struct {
+	// GeneratedAt is when the report was built
+	GeneratedAt time.Time
+	// Total is the number of ingresses
+	Total int
+	// Compliant is the number of audited ingresses without open finding
+	Compliant int
+	// Exempted is the number of ingresses exempted from the audit, they are neither compliant nor failing
+	Exempted int
+	// NotAudited is the number of ingresses the auditor has not audited yet, see Findings.NotAudited
+	NotAudited int
+	// Namespaces are the ingresses grouped by namespace, sorted by name
+	Namespaces []NamespaceCompliance
+	// Certificates are the certificates in the TLS secrets of the ingresses, sorted by expiry
+	Certificates []Certificate
+	// OpenFindings are the open findings, oldest first
+	OpenFindings []OpenFinding
+	// Estimated is the number of ingresses without IngressAuditStatus, whose open findings are estimated from their logs
+	Estimated int
+	// StaleAfter is the StaleAfter of the estimated findings
+	StaleAfter time.Duration
+}
+
+// NamespaceCompliance are the ingresses of a namespace
+type NamespaceCompliance struct {
+	Name      string
+	Compliant int
+	// InScope is the number of ingresses which are not exempted
+	InScope   int
+	Ingresses []IngressCompliance
+}
+
+// IngressCompliance is the state of an ingress
+type IngressCompliance struct {
+	Name  string
+	Hosts []string
+	// TLS is true if the ingress defines TLS blocks
+	TLS bool
+	// Finding is the open finding of the ingress, nil if it is compliant, exempted or not audited
+	Finding *OpenFinding
+	// ExemptUntil is the end of the exemption of the ingress, nil if it is not exempted
+	ExemptUntil *time.Time
+	// NotAudited is true if the auditor has not audited the ingress yet
+	NotAudited bool
+}
+
+// Certificate is a certificate used by ingresses
+type Certificate struct {
+	// Secret is the namespace/name of the secret
+	Secret      string    `json:"secret"`
+	Subject     string    `json:"subject"`
+	Issuer      string    `json:"issuer"`
+	DNSNames    []string  `json:"dnsNames"`
+	NotAfter    time.Time `json:"notAfter"`
+	Fingerprint string    `json:"fingerprint"`
+	// Ingresses are the namespace/name of the ingresses using the certificate
+	Ingresses []string `json:"ingresses"`
+}
+
+// OpenFinding is the current finding of an ingress in its IngressAuditStatus,
+// or without one the latest finding logged again within the stale period
+type OpenFinding struct {
+	Namespace string
+	Ingress   string
+	Host      string
+	Reason    string
+	Severity  string
+	Message   string
+	// FirstSeen is the first log of the finding in the uninterrupted history of the same reason,
+	// or the last transition of the failed condition of the IngressAuditStatus
+	FirstSeen time.Time
+	// LastSeen is the latest log or audit of the finding
+	LastSeen time.Time
+}
+
+// ComplianceOptions configures how the compliance report is built
+type ComplianceOptions struct {
+	// AuditStatuses hold the current findings of the ingresses they audit
+	AuditStatuses []ingressauditv1alpha1.IngressAuditStatus
+	// StaleAfter closes the findings of ingresses without IngressAuditStatus if they are not logged again
+	// within this duration, the reconciler logs persisting findings again after every interval, or after
+	// the re-notification backoff
+	StaleAfter time.Duration
+	// Now is the time of the report
+	Now time.Time
+	// AllowCrossNamespaceSecrets resolves secretName values like namespace/name, see the auditor flag
+	AllowCrossNamespaceSecrets bool
+}
+
+// InScope returns the number of ingresses which are not exempted
+func (c *Compliance) InScope() int {
+	return c.Total - c.Exempted
+}
+
+// Percentage returns the share of compliant ingresses among the ones not exempted, 100 if there are none
+func (c *Compliance) Percentage() float64 {
+	if c.InScope() == 0 {
+		return 100
+	}
+
+	return float64(c.Compliant) * 100 / float64(c.InScope())
+}
+
+// BuildCompliance builds the compliance report of the ingresses from their audit statuses, logs and TLS secrets.
+// The open findings of ingresses without IngressAuditStatus are estimated from their logs, see ComplianceOptions.StaleAfter.
+// Exempted and not audited ingresses are not compliant, the exempted ones are left out of the percentage.
+func BuildCompliance(
+	ingresses []networkingv1.Ingress,
+	logs []ingressauditv1alpha1.IngressTLSLog,
+	secrets controller.SecretLookup,
+	opts ComplianceOptions,
+) *Compliance {
+	c := &Compliance{GeneratedAt: opts.Now, Total: len(ingresses), StaleAfter: opts.StaleAfter}
+
+	findings := NewFindings(logs, opts)
+	namespaces := map[string]*NamespaceCompliance{}
+
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		key := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+
+		ns, ok := namespaces[ingress.Namespace]
+		if !ok {
+			ns = &NamespaceCompliance{Name: ingress.Namespace}
+			namespaces[ingress.Namespace] = ns
+		}
+
+		state := IngressCompliance{Name: ingress.Name, Hosts: HostsOf(ingress), TLS: len(ingress.Spec.TLS) != 0}
+		ns.Ingresses = append(ns.Ingresses, state)
+		current := &ns.Ingresses[len(ns.Ingresses)-1]
+
+		// The auditor skips exempted ingresses, their findings are not open
+		if until, exempted, _ := controller.ExemptUntil(ingress); exempted && opts.Now.Before(until) {
+			current.ExemptUntil = &until
+			c.Exempted++
+			continue
+		}
+		ns.InScope++
+
+		finding, estimated := findings.Of(key)
+		if estimated {
+			c.Estimated++
+		}
+
+		switch {
+		case finding != nil:
+			current.Finding = finding
+			c.OpenFindings = append(c.OpenFindings, *finding)
+		case findings.NotAudited(key):
+			current.NotAudited = true
+			c.NotAudited++
+		default:
+			ns.Compliant++
+			c.Compliant++
+		}
+	}
+
+	for _, ns := range namespaces {
+		sort.Slice(ns.Ingresses, func(i, j int) bool { return ns.Ingresses[i].Name < ns.Ingresses[j].Name })
+		c.Namespaces = append(c.Namespaces, *ns)
+	}
+	sort.Slice(c.Namespaces, func(i, j int) bool { return c.Namespaces[i].Name < c.Namespaces[j].Name })
+
+	c.Certificates = CertificateInventory(ingresses, secrets, opts.AllowCrossNamespaceSecrets)
+
+	sort.Slice(c.OpenFindings, func(i, j int) bool { return c.OpenFindings[i].FirstSeen.Before(c.OpenFindings[j].FirstSeen) })
+
+	return c
+}
+
+// Findings looks up the open findings of the ingresses in their audit statuses and logs
+type Findings struct {
+	statuses map[types.NamespacedName]*ingressauditv1alpha1.IngressAuditStatus
+	history  map[types.NamespacedName][]*ingressauditv1alpha1.IngressTLSLog
+	builtin  map[string]bool
+	opts     ComplianceOptions
+}
+
+// NewFindings indexes the logs and the audit statuses of the options by ingress
+func NewFindings(logs []ingressauditv1alpha1.IngressTLSLog, opts ComplianceOptions) *Findings {
+	f := &Findings{
+		statuses: map[types.NamespacedName]*ingressauditv1alpha1.IngressAuditStatus{},
+		history:  logHistory(logs),
+		builtin:  map[string]bool{},
+		opts:     opts,
+	}
+	for i := range opts.AuditStatuses {
+		status := &opts.AuditStatuses[i]
+		f.statuses[types.NamespacedName{Namespace: status.Namespace, Name: status.Spec.IngressName}] = status
+	}
+	for _, checker := range controller.BuiltinCheckers() {
+		for _, code := range checker.Reasons() {
+			f.builtin[code] = true
+		}
+	}
+
+	return f
+}
+
+// Of returns the open finding of the ingress, nil if it is compliant, and whether it is estimated because the
+// ingress has no IngressAuditStatus. The conditions of the IngressAuditStatus only hold the findings of the built-in
+// checks, so the findings of the custom rules are estimated from the logs too, see ComplianceOptions.StaleAfter.
+func (f *Findings) Of(key types.NamespacedName) (*OpenFinding, bool) {
+	status, ok := f.statuses[key]
+	if !ok {
+		return openFinding(key, f.history[key], f.opts), true
+	}
+
+	if finding := statusFinding(key, status, f.history[key]); finding != nil {
+		return finding, false
+	}
+
+	// The rules only run once the built-in checks passed
+	if finding := openFinding(key, f.history[key], f.opts); finding != nil && !f.builtin[finding.Reason] {
+		return finding, false
+	}
+
+	return nil, false
+}
+
+// NotAudited reports whether the ingress was not audited yet: the auditor writes audit statuses, but the ingress
+// has neither an IngressAuditStatus nor logs. Without any IngressAuditStatus an ingress without logs is compliant.
+func (f *Findings) NotAudited(key types.NamespacedName) bool {
+	_, ok := f.statuses[key]
+	return len(f.statuses) != 0 && !ok && len(f.history[key]) == 0
+}
+
+// CertificateInventory returns the certificates in the TLS secrets of the ingresses, sorted by expiry.
+// A secretName like namespace/name is only read from that namespace if allowCrossNamespace is set.
+func CertificateInventory(ingresses []networkingv1.Ingress, secrets controller.SecretLookup, allowCrossNamespace bool) []Certificate {
+	certificates := map[types.NamespacedName]*Certificate{}
+
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		key := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+
+			secretKey := types.NamespacedName{Namespace: ingress.Namespace, Name: tls.SecretName}
+			if allowCrossNamespace && strings.Contains(tls.SecretName, "/") {
+				ref, err := controller.ParseSecretReference(tls.SecretName)
+				if err != nil {
+					continue
+				}
+				secretKey = ref
+			}
+
+			if certificate, ok := certificates[secretKey]; ok {
+				certificate.Ingresses = appendUnique(certificate.Ingresses, key.String())
+				continue
+			}
+
+			secret, ok := secrets(secretKey)
+			if !ok {
+				continue
+			}
+			crt := secret.Data[v1.TLSCertKey]
+			cert, err := utils.ParseCertificate(crt)
+			if err != nil {
+				continue
+			}
+
+			certificates[secretKey] = &Certificate{
+				Secret:      secretKey.String(),
+				Subject:     cert.Subject.CommonName,
+				Issuer:      cert.Issuer.CommonName,
+				DNSNames:    cert.DNSNames,
+				NotAfter:    cert.NotAfter,
+				Fingerprint: utils.Fingerprint(crt),
+				Ingresses:   []string{key.String()},
+			}
+		}
+	}
+
+	inventory := make([]Certificate, 0, len(certificates))
+	for _, certificate := range certificates {
+		inventory = append(inventory, *certificate)
+	}
+	sort.Slice(inventory, func(i, j int) bool {
+		if !inventory[i].NotAfter.Equal(inventory[j].NotAfter) {
+			return inventory[i].NotAfter.Before(inventory[j].NotAfter)
+		}
+		return inventory[i].Secret < inventory[j].Secret
+	})
+
+	return inventory
+}
+
+// logHistory returns the logs of each ingress, newest first
+func logHistory(logs []ingressauditv1alpha1.IngressTLSLog) map[types.NamespacedName][]*ingressauditv1alpha1.IngressTLSLog {
+	history := map[types.NamespacedName][]*ingressauditv1alpha1.IngressTLSLog{}
+	for i := range logs {
+		log := &logs[i]
+		key := types.NamespacedName{Namespace: log.Spec.NameSpace, Name: log.Spec.IngressName}
+		history[key] = append(history[key], log)
+	}
+
+	for _, logs := range history {
+		sort.Slice(logs, func(i, j int) bool { return generationTime(logs[i]).After(generationTime(logs[j])) })
+	}
+
+	return history
+}
+
+// statusFinding returns the current finding of the IngressAuditStatus, nil if the ingress passed its last audit.
+// The host and severity are taken from the latest log of the reason.
+func statusFinding(
+	key types.NamespacedName,
+	status *ingressauditv1alpha1.IngressAuditStatus,
+	logs []*ingressauditv1alpha1.IngressTLSLog,
+) *OpenFinding {
+	condition := controller.CurrentFinding(status)
+	if condition == nil {
+		return nil
+	}
+
+	finding := &OpenFinding{
+		Namespace: key.Namespace,
+		Ingress:   key.Name,
+		Reason:    condition.Reason,
+		Severity:  controller.ErrLogLevel,
+		Message:   condition.Message,
+		FirstSeen: condition.LastTransitionTime.Time,
+		LastSeen:  condition.LastTransitionTime.Time,
+	}
+	if status.Status.LastCheckedTime != nil {
+		finding.LastSeen = status.Status.LastCheckedTime.Time
+	}
+
+	for _, log := range logs {
+		if log.Spec.Reason == condition.Reason {
+			finding.Host = log.Spec.Host
+			finding.Severity = log.Spec.LogLevel
+			break
+		}
+	}
+
+	return finding
+}
+
+// openFinding returns the latest finding of the logs of an ingress if it was logged within the stale period
+func openFinding(key types.NamespacedName, logs []*ingressauditv1alpha1.IngressTLSLog, opts ComplianceOptions) *OpenFinding {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	latest := logs[0]
+	if opts.StaleAfter > 0 && opts.Now.Sub(generationTime(latest)) > opts.StaleAfter {
+		return nil
+	}
+
+	finding := &OpenFinding{
+		Namespace: key.Namespace,
+		Ingress:   key.Name,
+		Host:      latest.Spec.Host,
+		Reason:    latest.Spec.Reason,
+		Severity:  latest.Spec.LogLevel,
+		Message:   latest.Spec.Message,
+		FirstSeen: generationTime(latest),
+		LastSeen:  generationTime(latest),
+	}
+
+	// The finding persists as long as the same reason is logged again
+	for _, log := range logs[1:] {
+		if log.Spec.Reason != latest.Spec.Reason || log.Spec.Message != latest.Spec.Message {
+			break
+		}
+		finding.FirstSeen = generationTime(log)
+	}
+
+	return finding
+}
+
+// generationTime returns the GenerationTimestamp of the log, or its creation if it is not set
+func generationTime(log *ingressauditv1alpha1.IngressTLSLog) time.Time {
+	if log.Spec.GenerationTimestamp != nil {
+		return log.Spec.GenerationTimestamp.Time
+	}
+
+	return log.CreationTimestamp.Time
+}
+
+// appendUnique appends the value if it is not in the slice yet
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+// complianceFuncs are available in the report templates
+func complianceFuncs(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"age": func(t time.Time) string {
+			return duration.HumanDuration(now.Sub(t))
+		},
+		"date": func(t time.Time) string {
+			return t.UTC().Format(time.DateOnly)
+		},
+		"duration": func(d time.Duration) string {
+			return duration.HumanDuration(d)
+		},
+		"daysLeft": func(t time.Time) int {
+			return int(t.Sub(now).Hours() / 24)
+		},
+		"join": strings.Join,
+		"percent": func(v float64) string {
+			return fmt.Sprintf("%.1f%%", v)
+		},
+		// md escapes the characters breaking a Markdown table cell
+		"md": func(s string) string {
+			return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+		},
+	}
+}
+
+// WriteCompliance writes the compliance report as self-contained HTML or Markdown
+func WriteCompliance(w io.Writer, format string, c *Compliance) error {
+	switch format {
+	case FormatHTML:
+		tmpl, err := htmltemplate.New(FormatHTML).Funcs(complianceFuncs(c.GeneratedAt)).Parse(complianceHTML)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(w, c)
+	case FormatMarkdown:
+		tmpl, err := template.New(FormatMarkdown).Funcs(complianceFuncs(c.GeneratedAt)).Parse(complianceMarkdown)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(w, c)
+	}
+
+	return fmt.Errorf("unknown compliance report format %q", format)
+}
+
+const complianceMarkdown = `# Ingress TLS compliance report
+
+Generated at {{ .GeneratedAt.UTC.Format "2006-01-02 15:04:05 MST" }}.
+
+**{{ percent .Percentage }}** of the ingresses are compliant ({{ .Compliant }} of {{ .InScope }}).
+{{ if .Exempted }}
+{{ .Exempted }} of the {{ .Total }} ingresses are exempted from the audit and left out of the percentage.
+{{ end }}{{ if .NotAudited }}
+{{ .NotAudited }} of the ingresses were not audited yet and are not compliant.
+{{ end }}{{ if .Estimated }}
+{{ .Estimated }} of the ingresses have no IngressAuditStatus. Their findings are estimated from their logs: a finding is open if it was logged within the last {{ duration .StaleAfter }}, so a finding logged less often, e.g. because of the re-notification backoff, is reported as resolved. Run the auditor with --audit-status for the current findings.
+{{ end }}
+## Namespaces
+{{ range .Namespaces }}
+### {{ .Name }}
+
+{{ .Compliant }} of {{ .InScope }} ingresses are compliant.
+
+| Ingress | Hosts | TLS | Status |
+|---|---|---|---|
+{{ range .Ingresses }}| {{ md .Name }} | {{ md (join .Hosts ", ") }} | {{ if .TLS }}yes{{ else }}no{{ end }} | {{ if .ExemptUntil }}Exempted until {{ date .ExemptUntil }}{{ else if .NotAudited }}Not audited{{ else }}{{ with .Finding }}{{ .Severity }}: {{ md .Reason }}{{ else }}Compliant{{ end }}{{ end }} |
+{{ end }}{{ end }}
+## Certificates
+{{ if .Certificates }}
+| Secret | Subject | Issuer | DNS names | Expires | Days left | Ingresses |
+|---|---|---|---|---|---|---|
+{{ range .Certificates }}| {{ md .Secret }} | {{ md .Subject }} | {{ md .Issuer }} | {{ md (join .DNSNames ", ") }} | {{ date .NotAfter }} | {{ daysLeft .NotAfter }} | {{ md (join .Ingresses ", ") }} |
+{{ end }}{{ else }}
+No certificates found.
+{{ end }}
+## Open findings
+{{ if .OpenFindings }}
+| Ingress | Host | Severity | Reason | Age | Message |
+|---|---|---|---|---|---|
+{{ range .OpenFindings }}| {{ md .Namespace }}/{{ md .Ingress }} | {{ md .Host }} | {{ .Severity }} | {{ md .Reason }} | {{ age .FirstSeen }} | {{ md .Message }} |
+{{ end }}{{ else }}
+No open findings.
+{{ end }}`
+
+const complianceHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Ingress TLS compliance report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+.summary { font-size: 1.4em; }
+.Error { color: #d70000; }
+.Warn { color: #c77700; }
+.Compliant { color: #2e7d32; }
+</style>
+</head>
+<body>
+<h1>Ingress TLS compliance report</h1>
+<p>Generated at {{ .GeneratedAt.UTC.Format "2006-01-02 15:04:05 MST" }}.</p>
+<p class="summary"><strong>{{ percent .Percentage }}</strong> of the ingresses are compliant ({{ .Compliant }} of {{ .InScope }}).</p>
+{{ if .Exempted }}<p>{{ .Exempted }} of the {{ .Total }} ingresses are exempted from the audit and left out of the percentage.</p>
+{{ end }}{{ if .NotAudited }}<p>{{ .NotAudited }} of the ingresses were not audited yet and are not compliant.</p>
+{{ end }}{{ if .Estimated }}<p>{{ .Estimated }} of the ingresses have no IngressAuditStatus. Their findings are estimated from their logs: a finding is open if it was logged within the last {{ duration .StaleAfter }}, so a finding logged less often, e.g. because of the re-notification backoff, is reported as resolved. Run the auditor with --audit-status for the current findings.</p>
+{{ end }}
+<h2>Namespaces</h2>
+{{ range .Namespaces }}
+<h3>{{ .Name }}</h3>
+<p>{{ .Compliant }} of {{ .InScope }} ingresses are compliant.</p>
+<table>
+<tr><th>Ingress</th><th>Hosts</th><th>TLS</th><th>Status</th></tr>
+{{ range .Ingresses }}<tr><td>{{ .Name }}</td><td>{{ join .Hosts ", " }}</td><td>{{ if .TLS }}yes{{ else }}no{{ end }}</td>
+{{ if .ExemptUntil }}<td>Exempted until {{ date .ExemptUntil }}</td>{{ else if .NotAudited }}<td>Not audited</td>
+{{ else }}{{ with .Finding }}<td class="{{ .Severity }}">{{ .Severity }}: {{ .Reason }}</td>{{ else }}<td class="Compliant">Compliant</td>{{ end }}{{ end }}</tr>
+{{ end }}</table>
+{{ end }}
+
+<h2>Certificates</h2>
+{{ if .Certificates }}<table>
+<tr><th>Secret</th><th>Subject</th><th>Issuer</th><th>DNS names</th><th>Expires</th><th>Days left</th><th>Ingresses</th></tr>
+{{ range .Certificates }}<tr><td>{{ .Secret }}</td><td>{{ .Subject }}</td><td>{{ .Issuer }}</td><td>{{ join .DNSNames ", " }}</td>
+<td>{{ date .NotAfter }}</td><td>{{ daysLeft .NotAfter }}</td><td>{{ join .Ingresses ", " }}</td></tr>
+{{ end }}</table>
+{{ else }}<p>No certificates found.</p>{{ end }}
+
+<h2>Open findings</h2>
+{{ if .OpenFindings }}<table>
+<tr><th>Ingress</th><th>Host</th><th>Severity</th><th>Reason</th><th>Age</th><th>Message</th></tr>
+{{ range .OpenFindings }}<tr><td>{{ .Namespace }}/{{ .Ingress }}</td><td>{{ .Host }}</td><td class="{{ .Severity }}">{{ .Severity }}</td>
+<td>{{ .Reason }}</td><td>{{ age .FirstSeen }}</td><td>{{ .Message }}</td></tr>
+{{ end }}</table>
+{{ else }}<p>No open findings.</p>{{ end }}
+</body>
+</html>
+`
@@ -0,0 +1,217 @@
+package report_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"slices"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ingressauditv1alpha1 "github.com/MMMMMMorty/ingress-auditor/api/v1alpha1"
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/report"
+)
+
+var _ = Describe("Compliance", func() {
+	now := time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC)
+
+	newIngress := func(namespace, name, host, secretName string) networkingv1.Ingress {
+		ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		ingress.Spec.Rules = []networkingv1.IngressRule{{Host: host}}
+		if secretName != "" {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{host}, SecretName: secretName}}
+		}
+		return ingress
+	}
+
+	newLog := func(namespace, ingress, reason string, at time.Time) ingressauditv1alpha1.IngressTLSLog {
+		return ingressauditv1alpha1.IngressTLSLog{Spec: ingressauditv1alpha1.IngressTLSLogSpec{
+			LogLevel:            "Error",
+			NameSpace:           namespace,
+			IngressName:         ingress,
+			Reason:              reason,
+			Message:             reason,
+			GenerationTimestamp: &metav1.Time{Time: at},
+		}}
+	}
+
+	newSecret := func(host string, notAfter time.Time) *v1.Secret {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: host},
+			DNSNames:     []string{host},
+			NotBefore:    notAfter.AddDate(-1, 0, 0),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+		return &v1.Secret{Data: map[string][]byte{v1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}}
+	}
+
+	secrets := map[types.NamespacedName]*v1.Secret{
+		{Namespace: "ns-1", Name: "a-tls"}: newSecret("a.foo.com", now.AddDate(0, 0, 10)),
+		{Namespace: "ns-1", Name: "b-tls"}: newSecret("b.foo.com", now.AddDate(0, 2, 0)),
+	}
+	lookup := func(key types.NamespacedName) (*v1.Secret, bool) {
+		secret, ok := secrets[key]
+		return secret, ok
+	}
+
+	ingresses := []networkingv1.Ingress{
+		newIngress("ns-1", "a", "a.foo.com", "a-tls"),
+		newIngress("ns-1", "b", "b.foo.com", "b-tls"),
+		newIngress("ns-2", "c", "c.foo.com", ""),
+		newIngress("ns-2", "d", "d.foo.com", ""),
+	}
+
+	logs := []ingressauditv1alpha1.IngressTLSLog{
+		// b was fixed long ago
+		newLog("ns-1", "b", "HostnameMismatch", now.Add(-72*time.Hour)),
+		// c persists for three hours after another reason
+		newLog("ns-2", "c", "SecretNameMissing", now.Add(-5*time.Hour)),
+		newLog("ns-2", "c", "HTTPRedirectMissing", now.Add(-3*time.Hour)),
+		newLog("ns-2", "c", "HTTPRedirectMissing", now.Add(-2*time.Hour)),
+		newLog("ns-2", "c", "HTTPRedirectMissing", now.Add(-1*time.Hour)),
+	}
+
+	compliance := report.BuildCompliance(ingresses, logs, lookup, report.ComplianceOptions{StaleAfter: 25 * time.Hour, Now: now})
+
+	It("should summarise the compliance per namespace", func() {
+		Expect(compliance.Total).To(Equal(4))
+		Expect(compliance.Compliant).To(Equal(3))
+		Expect(compliance.Percentage()).To(Equal(75.0))
+
+		Expect(compliance.Namespaces).To(HaveLen(2))
+		Expect(compliance.Namespaces[0].Compliant).To(Equal(2))
+		Expect(compliance.Namespaces[1].Compliant).To(Equal(1))
+		Expect(compliance.Namespaces[1].Ingresses[0].Finding.Reason).To(Equal("HTTPRedirectMissing"))
+	})
+
+	It("should age the open findings from the first log of the same reason", func() {
+		Expect(compliance.OpenFindings).To(HaveLen(1))
+		Expect(compliance.OpenFindings[0].FirstSeen).To(Equal(now.Add(-3 * time.Hour)))
+		Expect(compliance.OpenFindings[0].LastSeen).To(Equal(now.Add(-1 * time.Hour)))
+	})
+
+	It("should list the certificates by expiry", func() {
+		Expect(compliance.Certificates).To(HaveLen(2))
+		Expect(compliance.Certificates[0].Secret).To(Equal("ns-1/a-tls"))
+		Expect(compliance.Certificates[0].Ingresses).To(Equal([]string{"ns-1/a"}))
+		Expect(compliance.Certificates[1].Secret).To(Equal("ns-1/b-tls"))
+	})
+
+	It("should write HTML and Markdown", func() {
+		out := &bytes.Buffer{}
+		Expect(report.WriteCompliance(out, report.FormatHTML, compliance)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("<strong>75.0%</strong>"))
+		Expect(out.String()).To(ContainSubstring("<td>ns-2/c</td>"))
+
+		out.Reset()
+		Expect(report.WriteCompliance(out, report.FormatMarkdown, compliance)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("**75.0%** of the ingresses are compliant (3 of 4)."))
+		Expect(out.String()).To(ContainSubstring("| ns-1/a-tls | a.foo.com | a.foo.com | a.foo.com | 2025-12-22 | 10 | ns-1/a |"))
+		Expect(out.String()).To(ContainSubstring("| ns-2/c |  | Error | HTTPRedirectMissing | 3h | HTTPRedirectMissing |"))
+		Expect(out.String()).To(ContainSubstring("4 of the ingresses have no IngressAuditStatus."))
+		Expect(out.String()).To(ContainSubstring("logged within the last 25h"))
+	})
+
+	It("should take the open findings of ingresses with IngressAuditStatus from the status", func() {
+		newStatus := func(namespace, name string, conditions ...metav1.Condition) ingressauditv1alpha1.IngressAuditStatus {
+			return ingressauditv1alpha1.IngressAuditStatus{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+				Spec:       ingressauditv1alpha1.IngressAuditStatusSpec{IngressName: name},
+				Status: ingressauditv1alpha1.IngressAuditStatusStatus{
+					Conditions:      conditions,
+					LastCheckedTime: &metav1.Time{Time: now.Add(-time.Minute)},
+				},
+			}
+		}
+
+		// b is failing for two days but only logged again after the re-notification backoff, c was fixed
+		statusLogs := append(slices.Clone(logs), newLog("ns-1", "b", "HostnameMismatch", now.Add(-48*time.Hour)))
+		compliance := report.BuildCompliance(ingresses, statusLogs, lookup, report.ComplianceOptions{
+			AuditStatuses: []ingressauditv1alpha1.IngressAuditStatus{
+				newStatus("ns-1", "b", metav1.Condition{
+					Type:               ingressauditv1alpha1.CertificateValidCondition,
+					Status:             metav1.ConditionFalse,
+					Reason:             "HostnameMismatch",
+					Message:            "the certificate does not match b.foo.com",
+					LastTransitionTime: metav1.Time{Time: now.Add(-48 * time.Hour)},
+				}),
+				newStatus("ns-2", "c", metav1.Condition{
+					Type:   ingressauditv1alpha1.RedirectEnforcedCondition,
+					Status: metav1.ConditionTrue,
+					Reason: "ChecksPassed",
+				}),
+			},
+			StaleAfter: 25 * time.Hour,
+			Now:        now,
+		})
+
+		Expect(compliance.Estimated).To(Equal(2))
+		Expect(compliance.OpenFindings).To(HaveLen(1))
+		finding := compliance.OpenFindings[0]
+		Expect(finding.Ingress).To(Equal("b"))
+		Expect(finding.Reason).To(Equal("HostnameMismatch"))
+		Expect(finding.Message).To(Equal("the certificate does not match b.foo.com"))
+		Expect(finding.Severity).To(Equal("Error"))
+		Expect(finding.FirstSeen).To(Equal(now.Add(-48 * time.Hour)))
+		Expect(finding.LastSeen).To(Equal(now.Add(-time.Minute)))
+
+		// a and d have neither an IngressAuditStatus nor logs
+		Expect(compliance.NotAudited).To(Equal(2))
+		Expect(compliance.Compliant).To(Equal(1))
+		Expect(compliance.Namespaces[0].Ingresses[0].NotAudited).To(BeTrue())
+	})
+
+	It("should leave the exempted ingresses out of the percentage", func() {
+		exempted := newIngress("ns-1", "exempted", "e.foo.com", "")
+		exempted.Annotations = map[string]string{controller.ExemptUntilAnnotation: now.AddDate(0, 0, 7).Format(time.RFC3339)}
+		expired := newIngress("ns-1", "expired", "f.foo.com", "")
+		expired.Annotations = map[string]string{controller.ExemptUntilAnnotation: now.AddDate(0, 0, -1).Format(time.RFC3339)}
+		logs := []ingressauditv1alpha1.IngressTLSLog{
+			newLog("ns-1", "exempted", "HTTPRedirectMissing", now.Add(-time.Hour)),
+			newLog("ns-1", "expired", "HTTPRedirectMissing", now.Add(-time.Hour)),
+		}
+
+		compliance := report.BuildCompliance([]networkingv1.Ingress{exempted, expired}, logs, lookup, report.ComplianceOptions{
+			StaleAfter: 25 * time.Hour,
+			Now:        now,
+		})
+
+		Expect(compliance.Exempted).To(Equal(1))
+		Expect(compliance.InScope()).To(Equal(1))
+		Expect(compliance.Compliant).To(Equal(0))
+		Expect(compliance.OpenFindings).To(HaveLen(1))
+		Expect(compliance.OpenFindings[0].Ingress).To(Equal("expired"))
+		Expect(compliance.Namespaces[0].Ingresses[0].ExemptUntil).To(HaveValue(Equal(now.AddDate(0, 0, 7))))
+
+		out := &bytes.Buffer{}
+		Expect(report.WriteCompliance(out, report.FormatMarkdown, compliance)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("**0.0%** of the ingresses are compliant (0 of 1)."))
+		Expect(out.String()).To(ContainSubstring("| exempted | e.foo.com | no | Exempted until 2025-12-19 |"))
+	})
+
+	It("should only read secrets of other namespaces if they are allowed", func() {
+		ingresses := []networkingv1.Ingress{newIngress("ns-2", "shared", "a.foo.com", "ns-1/a-tls")}
+
+		Expect(report.CertificateInventory(ingresses, lookup, false)).To(BeEmpty())
+		inventory := report.CertificateInventory(ingresses, lookup, true)
+		Expect(inventory).To(HaveLen(1))
+		Expect(inventory[0].Secret).To(Equal("ns-1/a-tls"))
+	})
+})
@@ -0,0 +1,89 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	File      string          `xml:"file,attr,omitempty"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes one test suite per ingress and one test case per host.
+// Findings about the whole ingress fail all its hosts.
+func writeJUnit(w io.Writer, r *Report) error {
+	suites := junitTestSuites{Name: toolName}
+
+	for _, ingress := range r.Ingresses {
+		name := ingress.Namespace + "/" + ingress.Name
+		suite := junitTestSuite{Name: name, File: ingress.File}
+
+		for _, host := range ingress.Hosts {
+			testCase := junitTestCase{Name: host, ClassName: name}
+
+			var reasons, lines []string
+			for _, f := range r.Findings {
+				if f.Namespace != ingress.Namespace || f.Ingress != ingress.Name || (f.Host != "" && f.Host != host) {
+					continue
+				}
+				reasons = append(reasons, f.Reason)
+				lines = append(lines, fmt.Sprintf("[%s] %s: %s", f.Severity, f.Reason, f.Message))
+			}
+
+			if len(reasons) != 0 {
+				testCase.Failure = &junitFailure{
+					Message: strings.Join(reasons, ", "),
+					Type:    reasons[0],
+					Text:    strings.Join(lines, "\n"),
+				}
+				suite.Failures++
+			}
+
+			suite.TestCases = append(suite.TestCases, testCase)
+			suite.Tests++
+		}
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
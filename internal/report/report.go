@@ -0,0 +1,123 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+)
+
+// Formats of the report
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+	FormatJUnit = "junit"
+)
+
+// catchAllHost names the host of an ingress without hosts
+const catchAllHost = "*"
+
+// Report is the result of an audit
+type Report struct {
+	// Ingresses are the audited ingresses, so passing ingresses are reported too
+	Ingresses []Ingress `json:"ingresses"`
+	// Findings are the failed checks of the ingresses
+	Findings []controller.Finding `json:"findings"`
+}
+
+// Ingress is an audited ingress
+type Ingress struct {
+	// Namespace is the namespace of the ingress
+	Namespace string `json:"namespace"`
+	// Name is the name of the ingress
+	Name string `json:"name"`
+	// Hosts are the rule and TLS hosts of the ingress
+	Hosts []string `json:"hosts"`
+	// File is the manifest the ingress was read from, empty if it was not read from a file
+	File string `json:"file,omitempty"`
+	// Line is the first line of the ingress in the file
+	Line int `json:"line,omitempty"`
+}
+
+// HostsOf returns the sorted rule and TLS hosts of the ingress, or * if it has none
+func HostsOf(ingress *networkingv1.Ingress) []string {
+	seen := map[string]bool{}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			seen[rule.Host] = true
+		}
+	}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			seen[host] = true
+		}
+	}
+
+	if len(seen) == 0 {
+		return []string{catchAllHost}
+	}
+
+	hosts := make([]string, 0, len(seen))
+	for host := range seen {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	return hosts
+}
+
+// ValidFormat reports whether the format can be written
+func ValidFormat(format string) bool {
+	switch format {
+	case FormatTable, FormatJSON, FormatSARIF, FormatJUnit:
+		return true
+	}
+
+	return false
+}
+
+// Write writes the report in the format
+func Write(w io.Writer, format string, r *Report) error {
+	switch format {
+	case FormatTable:
+		return writeTable(w, r)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case FormatSARIF:
+		return writeSARIF(w, r)
+	case FormatJUnit:
+		return writeJUnit(w, r)
+	}
+
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+// writeTable writes one row per finding
+func writeTable(w io.Writer, r *Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAMESPACE\tINGRESS\tHOST\tSEVERITY\tREASON\tMESSAGE")
+	for _, f := range r.Findings {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", f.Namespace, f.Ingress, f.Host, f.Severity, f.Reason, f.Message)
+	}
+
+	return tw.Flush()
+}
+
+// ingressOf returns the audited ingress of the finding, nil if it is unknown
+func (r *Report) ingressOf(f controller.Finding) *Ingress {
+	for i := range r.Ingresses {
+		if r.Ingresses[i].Namespace == f.Namespace && r.Ingresses[i].Name == f.Ingress {
+			return &r.Ingresses[i]
+		}
+	}
+
+	return nil
+}
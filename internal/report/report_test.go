@@ -0,0 +1,150 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/report"
+)
+
+// sarifLog is the part of the SARIF log checked by the tests
+type sarifLog struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Rules []struct {
+					ID               string `json:"id"`
+					ShortDescription struct {
+						Text string `json:"text"`
+					} `json:"shortDescription"`
+					DefaultConfiguration struct {
+						Level string `json:"level"`
+					} `json:"defaultConfiguration"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex int    `json:"ruleIndex"`
+			Level     string `json:"level"`
+			Message   struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int `json:"startLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+				LogicalLocations []struct {
+					FullyQualifiedName string `json:"fullyQualifiedName"`
+				} `json:"logicalLocations"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+// junitTestSuites is the part of the JUnit report checked by the tests
+type junitTestSuites struct {
+	Tests    int `xml:"tests,attr"`
+	Failures int `xml:"failures,attr"`
+	Suites   []struct {
+		TestCases []struct {
+			Name    string `xml:"name,attr"`
+			Failure *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"failure"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+var _ = Describe("Report", func() {
+	r := &report.Report{
+		Ingresses: []report.Ingress{
+			{Namespace: "ns-1", Name: "ingress-1", Hosts: []string{"a.foo.com", "b.foo.com"}, File: "deploy/ingress.yaml", Line: 12},
+			{Namespace: "ns-2", Name: "ingress-2", Hosts: []string{"c.foo.com"}},
+		},
+		Findings: []controller.Finding{
+			{Namespace: "ns-1", Ingress: "ingress-1", Host: "b.foo.com", Reason: "CertificateSANMismatch",
+				Category: controller.CategoryCertificate, Severity: controller.ErrLogLevel, Message: "not covered"},
+			{Namespace: "ns-1", Ingress: "ingress-1", Reason: "CertificateExpiringSoon",
+				Category: controller.CategoryCertificate, Severity: controller.WarnLogLevel, Message: "expires soon"},
+		},
+	}
+
+	It("should write SARIF with a rule per reason and the location of the manifest", func() {
+		out := &bytes.Buffer{}
+		Expect(report.Write(out, report.FormatSARIF, r)).To(Succeed())
+
+		log := sarifLog{}
+		Expect(json.Unmarshal(out.Bytes(), &log)).To(Succeed())
+		Expect(log.Version).To(Equal("2.1.0"))
+		Expect(log.Runs).To(HaveLen(1))
+
+		run := log.Runs[0]
+		Expect(run.Tool.Driver.Rules).To(HaveLen(len(controller.Reasons())))
+		Expect(run.Results).To(HaveLen(2))
+
+		result := run.Results[0]
+		Expect(result.RuleID).To(Equal("CertificateSANMismatch"))
+		Expect(run.Tool.Driver.Rules[result.RuleIndex].ID).To(Equal(result.RuleID))
+		Expect(result.Level).To(Equal("error"))
+		Expect(result.Message.Text).To(Equal("b.foo.com: not covered"))
+		Expect(result.Locations[0].PhysicalLocation.ArtifactLocation.URI).To(Equal("deploy/ingress.yaml"))
+		Expect(result.Locations[0].PhysicalLocation.Region.StartLine).To(Equal(12))
+		Expect(result.Locations[0].LogicalLocations[0].FullyQualifiedName).To(Equal("ns-1/ingress-1"))
+
+		Expect(run.Results[1].Level).To(Equal("warning"))
+
+		rule := run.Tool.Driver.Rules[result.RuleIndex]
+		reason, ok := controller.ReasonByCode(rule.ID)
+		Expect(ok).To(BeTrue())
+		Expect(rule.ShortDescription.Text).To(Equal(reason.Description))
+		Expect(rule.DefaultConfiguration.Level).To(Equal("error"))
+
+		levels := map[string]string{}
+		for _, rule := range run.Tool.Driver.Rules {
+			levels[rule.ID] = rule.DefaultConfiguration.Level
+		}
+		Expect(levels).To(HaveKeyWithValue("CertificateExpiringSoon", "warning"))
+	})
+
+	It("should write JUnit with a test case per host", func() {
+		out := &bytes.Buffer{}
+		Expect(report.Write(out, report.FormatJUnit, r)).To(Succeed())
+
+		suites := junitTestSuites{}
+		Expect(xml.Unmarshal(out.Bytes(), &suites)).To(Succeed())
+		Expect(suites.Tests).To(Equal(3))
+		Expect(suites.Failures).To(Equal(2))
+		Expect(suites.Suites).To(HaveLen(2))
+
+		cases := suites.Suites[0].TestCases
+		Expect(cases).To(HaveLen(2))
+		Expect(cases[0].Name).To(Equal("a.foo.com"))
+		Expect(cases[0].Failure.Message).To(Equal("CertificateExpiringSoon"))
+		Expect(cases[1].Failure.Message).To(Equal("CertificateSANMismatch, CertificateExpiringSoon"))
+
+		Expect(suites.Suites[1].TestCases[0].Failure).To(BeNil())
+	})
+
+	It("should write JSON and reject unknown formats", func() {
+		out := &bytes.Buffer{}
+		Expect(report.Write(out, report.FormatJSON, r)).To(Succeed())
+
+		decoded := &report.Report{}
+		Expect(json.Unmarshal(out.Bytes(), decoded)).To(Succeed())
+		Expect(decoded).To(Equal(r))
+
+		Expect(report.Write(out, "html", r)).To(MatchError(ContainSubstring("unknown report format")))
+	})
+})
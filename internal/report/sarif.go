@@ -0,0 +1,157 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+)
+
+// SARIF 2.1.0 identifiers of the report
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "ingress-audit"
+	toolURI      = "https://github.com/MMMMMMorty/ingress-auditor"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string            `json:"id"`
+	Name                 string            `json:"name"`
+	ShortDescription     sarifMessage      `json:"shortDescription"`
+	FullDescription      sarifMessage      `json:"fullDescription"`
+	DefaultConfiguration sarifRuleConfig   `json:"defaultConfiguration"`
+	Properties           map[string]string `json:"properties"`
+}
+
+type sarifRuleConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifLevel maps the severity to the SARIF level
+func sarifLevel(severity string) string {
+	switch severity {
+	case controller.ErrLogLevel:
+		return "error"
+	case controller.WarnLogLevel:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// writeSARIF writes a SARIF 2.1.0 log with one rule per reason and one result per finding
+func writeSARIF(w io.Writer, r *Report) error {
+	reasons := controller.Reasons()
+	ruleIndex := map[string]int{}
+	rules := make([]sarifRule, 0, len(reasons))
+	for i, reason := range reasons {
+		ruleIndex[reason.Code] = i
+		rules = append(rules, sarifRule{
+			ID:                   reason.Code,
+			Name:                 reason.Code,
+			ShortDescription:     sarifMessage{Text: reason.Description},
+			FullDescription:      sarifMessage{Text: reason.Description},
+			DefaultConfiguration: sarifRuleConfig{Level: sarifLevel(reason.DefaultSeverity())},
+			Properties:           map[string]string{"category": reason.Category},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(r.Findings))
+	for _, f := range r.Findings {
+		name := f.Namespace + "/" + f.Ingress
+		location := sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{{Name: f.Ingress, FullyQualifiedName: name, Kind: "resource"}},
+		}
+		if ingress := r.ingressOf(f); ingress != nil && ingress.File != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(ingress.File)},
+			}
+			if ingress.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: ingress.Line}
+			}
+		}
+
+		message := f.Message
+		if f.Host != "" {
+			message = f.Host + ": " + message
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    f.Reason,
+			RuleIndex: ruleIndex[f.Reason],
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: toolName, InformationURI: toolURI, Rules: rules}},
+			Results: results,
+		}},
+	})
+}
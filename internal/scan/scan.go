@@ -0,0 +1,322 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/report"
+)
+
+// Annotations selecting the class of an ingress
+const (
+	ingressClassAnnotation        = "kubernetes.io/ingress.class"
+	defaultIngressClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+)
+
+// Manifests are the objects read from the manifests which are relevant for the checks
+type Manifests struct {
+	Ingresses      []*networkingv1.Ingress
+	Secrets        map[types.NamespacedName]*v1.Secret
+	IngressClasses []*networkingv1.IngressClass
+	// Locations are the files and lines the ingresses were read from
+	Locations map[types.NamespacedName]Location
+}
+
+// Location is where an object starts in the manifests
+type Location struct {
+	// File is the path of the manifest, empty for stdin
+	File string
+	// Line is the first line of the object, starting at 1
+	Line int
+}
+
+// document is a YAML document and the line its content starts at
+type document struct {
+	data []byte
+	line int
+}
+
+// Options configures the scan
+type Options struct {
+	controller.StaticOptions
+	// Namespace is used for the objects without namespace, like the output of helm template
+	Namespace string
+	// IngressClass only scans the ingresses of the class if it is set
+	IngressClass string
+}
+
+// LoadPaths reads the manifests of the files, the YAML and JSON files in the directories, or stdin for -
+func LoadPaths(paths []string, namespace string, stdin io.Reader) (*Manifests, error) {
+	m := &Manifests{
+		Secrets:   map[types.NamespacedName]*v1.Secret{},
+		Locations: map[types.NamespacedName]Location{},
+	}
+
+	for _, path := range paths {
+		if path == "-" {
+			if err := m.Load(stdin, "", namespace); err != nil {
+				return nil, fmt.Errorf("failed to read stdin: %w", err)
+			}
+			continue
+		}
+
+		err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			// Files named explicitly are read whatever their extension is
+			ext := strings.ToLower(filepath.Ext(file))
+			if file != path && ext != ".yaml" && ext != ".yml" && ext != ".json" {
+				return nil
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = f.Close() }()
+
+			if err := m.Load(f, file, namespace); err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Load reads the YAML or JSON documents of the file, objects of other kinds are ignored
+func (m *Manifests) Load(r io.Reader, file, namespace string) error {
+	docs, err := splitDocuments(r)
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		if err := m.add(doc.data, Location{File: file, Line: doc.line}, namespace); err != nil {
+			return fmt.Errorf("line %d: %w", doc.line, err)
+		}
+	}
+
+	return nil
+}
+
+// isSeparator reports whether the line separates two YAML documents. The separator starts at column 0,
+// an indented --- is content, e.g. of a block scalar, and only a comment may follow it.
+func isSeparator(line []byte) bool {
+	rest, ok := bytes.CutPrefix(line, []byte("---"))
+	if !ok {
+		return false
+	}
+
+	rest = bytes.TrimSpace(rest)
+	return len(rest) == 0 || rest[0] == '#'
+}
+
+// splitDocuments splits the YAML stream at the --- separators, remembering the line each document starts at
+func splitDocuments(r io.Reader) ([]document, error) {
+	reader := bufio.NewReader(r)
+
+	var docs []document
+	var buf bytes.Buffer
+	start, lineNo := 0, 0
+
+	flush := func() {
+		if start != 0 {
+			docs = append(docs, document{data: bytes.Clone(buf.Bytes()), line: start})
+		}
+		buf.Reset()
+		start = 0
+	}
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) != 0 {
+			lineNo++
+
+			trimmed := bytes.TrimSpace(line)
+			switch {
+			case isSeparator(line):
+				flush()
+			default:
+				// Comments before the object do not count as its start
+				if start == 0 && len(trimmed) != 0 && trimmed[0] != '#' {
+					start = lineNo
+				}
+				buf.Write(line)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			flush()
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// add decodes the document by its kind
+func (m *Manifests) add(doc []byte, location Location, namespace string) error {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+		return err
+	}
+
+	switch typeMeta.Kind {
+	case "List", "IngressList", "SecretList", "IngressClassList":
+		list := struct {
+			Items []json.RawMessage `json:"items"`
+		}{}
+		if err := yaml.Unmarshal(doc, &list); err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			if err := m.add(item, location, namespace); err != nil {
+				return err
+			}
+		}
+
+	case "Ingress":
+		ingress := &networkingv1.Ingress{}
+		if err := yaml.Unmarshal(doc, ingress); err != nil {
+			return fmt.Errorf("invalid ingress: %w", err)
+		}
+		if ingress.Namespace == "" {
+			ingress.Namespace = namespace
+		}
+		m.Ingresses = append(m.Ingresses, ingress)
+		m.Locations[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}] = location
+
+	case "Secret":
+		secret := &v1.Secret{}
+		if err := yaml.Unmarshal(doc, secret); err != nil {
+			return fmt.Errorf("invalid secret: %w", err)
+		}
+		if secret.Namespace == "" {
+			secret.Namespace = namespace
+		}
+		// stringData is merged into data by the API server
+		for key, value := range secret.StringData {
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			secret.Data[key] = []byte(value)
+		}
+		m.Secrets[types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}] = secret
+
+	case "IngressClass":
+		class := &networkingv1.IngressClass{}
+		if err := yaml.Unmarshal(doc, class); err != nil {
+			return fmt.Errorf("invalid ingress class: %w", err)
+		}
+		m.IngressClasses = append(m.IngressClasses, class)
+	}
+
+	return nil
+}
+
+// Scan runs the static checks on the ingresses, sorted by namespace and name
+func Scan(m *Manifests, opts Options) *report.Report {
+	lookup := func(key types.NamespacedName) (*v1.Secret, bool) {
+		secret, ok := m.Secrets[key]
+		return secret, ok
+	}
+
+	ingresses := make([]*networkingv1.Ingress, 0, len(m.Ingresses))
+	for _, ingress := range m.Ingresses {
+		if opts.IngressClass == "" || m.classOf(ingress) == opts.IngressClass {
+			ingresses = append(ingresses, ingress)
+		}
+	}
+
+	sort.SliceStable(ingresses, func(i, j int) bool {
+		if ingresses[i].Namespace != ingresses[j].Namespace {
+			return ingresses[i].Namespace < ingresses[j].Namespace
+		}
+		return ingresses[i].Name < ingresses[j].Name
+	})
+
+	r := &report.Report{}
+	for _, ingress := range ingresses {
+		location := m.Locations[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}]
+		r.Ingresses = append(r.Ingresses, report.Ingress{
+			Namespace: ingress.Namespace,
+			Name:      ingress.Name,
+			Hosts:     report.HostsOf(ingress),
+			File:      location.File,
+			Line:      location.Line,
+		})
+		r.Findings = append(r.Findings, controller.CheckIngress(ingress, lookup, opts.StaticOptions)...)
+	}
+
+	return r
+}
+
+// classOf returns the class of the ingress, falling back to the default IngressClass of the manifests
+func (m *Manifests) classOf(ingress *networkingv1.Ingress) string {
+	if ingress.Spec.IngressClassName != nil {
+		return *ingress.Spec.IngressClassName
+	}
+
+	if class := ingress.Annotations[ingressClassAnnotation]; class != "" {
+		return class
+	}
+
+	for _, class := range m.IngressClasses {
+		if class.Annotations[defaultIngressClassAnnotation] == "true" {
+			return class.Name
+		}
+	}
+
+	return ""
+}
+
+// Exceeds reports whether any finding is at or above the severity threshold, which is Error, Warn or Info
+func Exceeds(findings []controller.Finding, threshold string) bool {
+	for _, finding := range findings {
+		if severityRank[finding.Severity] >= severityRank[threshold] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// severityRank orders the severities
+var severityRank = map[string]int{
+	controller.InfoLogLevel: 1,
+	controller.WarnLogLevel: 2,
+	controller.ErrLogLevel:  3,
+}
+
+// ValidSeverity reports whether the severity can be used as threshold
+func ValidSeverity(severity string) bool {
+	_, ok := severityRank[severity]
+	return ok
+}
@@ -0,0 +1,227 @@
+package scan
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/MMMMMMorty/ingress-auditor/internal/controller"
+	"github.com/MMMMMMorty/ingress-auditor/internal/policy"
+)
+
+// newCertificate returns a self-signed PEM certificate for the hosts
+func newCertificate(notAfter time.Time, hosts ...string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hosts[0]},
+		DNSNames:     hosts,
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// secretYAML returns the manifest of a TLS secret with the certificate in stringData
+func secretYAML(name, crt string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Secret
+metadata:
+  name: %s
+type: kubernetes.io/tls
+stringData:
+  tls.key: key
+  tls.crt: |
+%s`, name, "    "+strings.ReplaceAll(strings.TrimSpace(crt), "\n", "\n    "))
+}
+
+// ingressYAML returns the manifest of an ingress routing and protecting the host with the secret
+func ingressYAML(name, secretName, host string, annotations string) string {
+	return fmt.Sprintf(`apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: %s
+  annotations: {%s}
+spec:
+  tls:
+  - hosts: [%s]
+    secretName: %s
+  rules:
+  - host: %s
+`, name, annotations, host, secretName, host)
+}
+
+// summary returns the ingress, reason and severity of the finding
+func summary(f controller.Finding) string {
+	return f.Ingress + " " + f.Reason + " " + f.Severity
+}
+
+var _ = Describe("Scan", func() {
+	now := time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC)
+	opts := Options{
+		StaticOptions: controller.StaticOptions{ExpiryWarning: 30 * 24 * time.Hour, Now: now},
+		Namespace:     "default",
+	}
+
+	load := func(docs ...string) *Manifests {
+		m, err := LoadPaths([]string{"-"}, "default", strings.NewReader(strings.Join(docs, "\n---\n")))
+		Expect(err).NotTo(HaveOccurred())
+		return m
+	}
+
+	It("should not report valid ingresses", func() {
+		m := load(
+			secretYAML("valid-tls", newCertificate(now.AddDate(1, 0, 0), "a.foo.com")),
+			ingressYAML("valid", "valid-tls", "a.foo.com", ""),
+		)
+
+		Expect(m.Ingresses).To(HaveLen(1))
+		Expect(Scan(m, opts).Findings).To(BeEmpty())
+	})
+
+	It("should report expired, expiring and mismatching certificates", func() {
+		m := load(
+			secretYAML("expired-tls", newCertificate(now.AddDate(0, 0, -1), "a.foo.com")),
+			ingressYAML("expired", "expired-tls", "a.foo.com", ""),
+			secretYAML("expiring-tls", newCertificate(now.AddDate(0, 0, 7), "b.foo.com")),
+			ingressYAML("expiring", "expiring-tls", "b.foo.com", ""),
+			secretYAML("other-tls", newCertificate(now.AddDate(1, 0, 0), "other.foo.com")),
+			ingressYAML("mismatch", "other-tls", "c.foo.com", ""),
+		)
+
+		findings := Scan(m, opts).Findings
+		Expect(findings).To(HaveLen(3))
+		Expect(summary(findings[0])).To(Equal("expired CertificateExpired " + controller.ErrLogLevel))
+		Expect(summary(findings[1])).To(Equal("expiring CertificateExpiringSoon " + controller.WarnLogLevel))
+		Expect(summary(findings[2])).To(Equal("mismatch CertificateSANMismatch " + controller.ErrLogLevel))
+		Expect(findings[2].Host).To(Equal("c.foo.com"))
+
+		Expect(Exceeds(findings, controller.ErrLogLevel)).To(BeTrue())
+		Expect(Exceeds(findings[1:2], controller.ErrLogLevel)).To(BeFalse())
+		Expect(Exceeds(findings[1:2], controller.WarnLogLevel)).To(BeTrue())
+	})
+
+	It("should report missing secrets as Warn unless cert-manager issues them", func() {
+		m := load(
+			ingressYAML("missing", "missing-tls", "a.foo.com", ""),
+			ingressYAML("issued", "issued-tls", "b.foo.com", `"cert-manager.io/cluster-issuer": letsencrypt`),
+		)
+
+		findings := Scan(m, opts).Findings
+		Expect(findings).To(HaveLen(1))
+		Expect(summary(findings[0])).To(Equal("missing FetchSecret " + controller.WarnLogLevel))
+	})
+
+	It("should skip the disabled checkers and run the rules of the policy", func() {
+		p, err := policy.Parse([]byte(`
+rules:
+- name: scan-issuer
+  expression: certificates.all(c, c.issuerCommonName == "letsencrypt")
+  reason: ScanIssuerRuleTest
+  severity: Info
+  message: the certificates must be issued by letsencrypt
+`))
+		Expect(err).NotTo(HaveOccurred())
+		checkers, err := controller.NewPolicyCheckerRegistry(p.Rules, []string{controller.CertificateSANChecker})
+		Expect(err).NotTo(HaveOccurred())
+
+		m := load(
+			secretYAML("other-tls", newCertificate(now.AddDate(1, 0, 0), "other.foo.com")),
+			ingressYAML("mismatch", "other-tls", "c.foo.com", ""),
+		)
+
+		static := opts.StaticOptions
+		static.Checkers = checkers
+		findings := Scan(m, Options{StaticOptions: static}).Findings
+		Expect(findings).To(HaveLen(1))
+		Expect(summary(findings[0])).To(Equal("mismatch ScanIssuerRuleTest " + controller.InfoLogLevel))
+
+		Expect(Exceeds(findings, controller.WarnLogLevel)).To(BeFalse())
+		Expect(Exceeds(findings, controller.InfoLogLevel)).To(BeTrue())
+	})
+
+	It("should locate the ingresses in multi-document YAML", func() {
+		m := load(
+			"# leading comment\napiVersion: networking.k8s.io/v1\nkind: IngressClass\nmetadata:\n  name: nginx",
+			"# ingress\n\n"+ingressYAML("a", "a-tls", "a.foo.com", ""),
+		)
+
+		// The ingress starts after the class, the separator and its comment
+		Expect(m.Locations).To(HaveKeyWithValue(types.NamespacedName{Namespace: "default", Name: "a"}, Location{Line: 9}))
+	})
+
+	It("should not split documents at an indented separator", func() {
+		m := load(`apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: snippet
+  annotations:
+    nginx.ingress.kubernetes.io/configuration-snippet: |
+      ---
+      more_set_headers "X-Frame-Options: DENY";
+spec:
+  rules:
+  - host: a.foo.com
+--- # the next document
+` + ingressYAML("a", "a-tls", "a.foo.com", ""))
+
+		Expect(m.Ingresses).To(HaveLen(2))
+		Expect(m.Ingresses[0].Annotations["nginx.ingress.kubernetes.io/configuration-snippet"]).To(HavePrefix("---\n"))
+		Expect(m.Locations).To(HaveKeyWithValue(types.NamespacedName{Namespace: "default", Name: "a"}, Location{Line: 13}))
+	})
+
+	It("should only scan the ingresses of the class", func() {
+		m := load(
+			`apiVersion: networking.k8s.io/v1
+kind: IngressClass
+metadata:
+  name: nginx
+  annotations:
+    ingressclass.kubernetes.io/is-default-class: "true"`,
+			ingressYAML("default-class", "missing-tls", "a.foo.com", ""),
+			ingressYAML("other-class", "missing-tls", "b.foo.com", `"kubernetes.io/ingress.class": traefik`),
+		)
+
+		findings := Scan(m, Options{StaticOptions: opts.StaticOptions, IngressClass: "nginx"}).Findings
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Ingress).To(Equal("default-class"))
+	})
+
+	It("should read the YAML files of directories and lists", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "ingress.yaml"), []byte(ingressYAML("a", "", "a.foo.com", "")), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "list.json"), []byte(`{"kind": "List", "items": [`+
+			`{"apiVersion": "networking.k8s.io/v1", "kind": "Ingress", "metadata": {"name": "b", "namespace": "ns"}}]}`), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a manifest"), 0o600)).To(Succeed())
+
+		m, err := LoadPaths([]string{dir}, "default", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Ingresses).To(HaveLen(2))
+		Expect(m.Locations).To(HaveKeyWithValue(types.NamespacedName{Namespace: "default", Name: "a"},
+			Location{File: filepath.Join(dir, "ingress.yaml"), Line: 1}))
+
+		findings := Scan(m, opts).Findings
+		Expect(findings).To(HaveLen(2))
+		Expect(summary(findings[0])).To(Equal("a SecretNameMissing " + controller.ErrLogLevel))
+		Expect(summary(findings[1])).To(Equal("b HTTPRedirectMissing " + controller.ErrLogLevel))
+		Expect(findings[1].Namespace).To(Equal("ns"))
+	})
+})
@@ -30,6 +30,17 @@ func (i *IngressErrorMap) Get(key string) (error, bool) {
 	return v, ok
 }
 
+// List returns a copy of the map
+func (i *IngressErrorMap) List() map[string]error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	m := make(map[string]error, len(i.m))
+	for k, v := range i.m {
+		m[k] = v
+	}
+	return m
+}
+
 // Delete removes a key from the map
 func (i *IngressErrorMap) Delete(key string) {
 	i.mu.Lock()
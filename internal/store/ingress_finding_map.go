@@ -0,0 +1,64 @@
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// Finding tracks the age and repetition of the error of an ingress
+type Finding struct {
+	// FirstSeen is when the error was logged first
+	FirstSeen time.Time
+	// Repeats counts how often the error was logged again since FirstSeen
+	Repeats int
+	// Severity is the level the error was logged with last
+	Severity string
+	// FixedSeverity is true if the severity is set by the checker of the error and not escalated
+	FixedSeverity bool
+	// Host is the ingress host of the error, empty if it is about the whole ingress
+	Host string
+}
+
+type IngressFindingMap struct {
+	mu sync.RWMutex
+	m  map[string]Finding
+}
+
+func NewIngressFindingMap() *IngressFindingMap {
+	return &IngressFindingMap{
+		m: make(map[string]Finding),
+	}
+}
+
+// Set key and value to IngressFindingMap
+func (i *IngressFindingMap) Set(key string, finding Finding) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.m[key] = finding
+}
+
+// Get uses key to get value
+func (i *IngressFindingMap) Get(key string) (Finding, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	v, ok := i.m[key]
+	return v, ok
+}
+
+// List returns a copy of the map
+func (i *IngressFindingMap) List() map[string]Finding {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	m := make(map[string]Finding, len(i.m))
+	for k, v := range i.m {
+		m[k] = v
+	}
+	return m
+}
+
+// Delete removes a key from the map
+func (i *IngressFindingMap) Delete(key string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.m, key)
+}
@@ -30,3 +30,21 @@ func (i *IngressUpdateTimeMap) Get(key string) (time.Time, bool) {
 	v, ok := i.m[key]
 	return v, ok
 }
+
+// List returns a copy of the map
+func (i *IngressUpdateTimeMap) List() map[string]time.Time {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	m := make(map[string]time.Time, len(i.m))
+	for k, v := range i.m {
+		m[k] = v
+	}
+	return m
+}
+
+// Delete removes a key from the map
+func (i *IngressUpdateTimeMap) Delete(key string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.m, key)
+}
@@ -1,11 +1,17 @@
 package utils
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -13,8 +19,48 @@ import (
 
 const httpsPort = 443
 
-// CheckTLS used the []byte PEM crt and PEM key to connect host with HTTPS
-func CheckTLS(log logr.Logger, crtPEM, keyPEM []byte, host string) error {
+// Failures of the TLS probe, CheckTLS wraps exactly one of them.
+// Network failures mean the host could not be reached,
+// certificate failures mean the host served an invalid certificate.
+var (
+	ErrDNSResolution          = errors.New("DNS resolution of the host failed")
+	ErrConnectionRefused      = errors.New("connection to the host was refused")
+	ErrConnectionTimeout      = errors.New("connection to the host timed out")
+	ErrNetworkUnreachable     = errors.New("the host is unreachable")
+	ErrHandshake              = errors.New("TLS handshake failed")
+	ErrHostnameMismatch       = errors.New("the certificate does not match the host")
+	ErrCertificateExpired     = errors.New("the certificate is expired")
+	ErrCertificateNotYetValid = errors.New("the certificate is not yet valid")
+	ErrUnknownAuthority       = errors.New("the certificate is signed by an unknown authority")
+)
+
+// ProbeTarget describes where the TLS probe connects to and which host it verifies
+type ProbeTarget struct {
+	// Host is sent as SNI and verified against the certificate
+	Host string
+	// Address is the IP or hostname dialed, defaults to Host when empty
+	Address string
+	// Port is the port dialed, defaults to 443 when zero
+	Port int
+}
+
+// DialAddress returns the host:port the probe connects to
+func (t ProbeTarget) DialAddress() string {
+	address := t.Address
+	if address == "" {
+		address = t.Host
+	}
+
+	port := t.Port
+	if port == 0 {
+		port = httpsPort
+	}
+
+	return net.JoinHostPort(address, strconv.Itoa(port))
+}
+
+// CheckTLS used the []byte PEM crt and PEM key to connect the target with HTTPS
+func CheckTLS(log logr.Logger, crtPEM, keyPEM []byte, target ProbeTarget) error {
 
 	// Root CA pool
 	rootCAs := x509.NewCertPool()
@@ -22,7 +68,7 @@ func CheckTLS(log logr.Logger, crtPEM, keyPEM []byte, host string) error {
 
 	tlsConfig := &tls.Config{
 		RootCAs:    rootCAs,
-		ServerName: host, // important: SNI
+		ServerName: target.Host, // important: SNI, independent of the dialed address
 	}
 
 	dialer := &net.Dialer{
@@ -30,11 +76,11 @@ func CheckTLS(log logr.Logger, crtPEM, keyPEM []byte, host string) error {
 		KeepAlive: 5 * time.Second,
 	}
 
-	address := net.JoinHostPort(host, strconv.Itoa(httpsPort))
+	address := target.DialAddress()
 
 	conn, err := tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
 	if err != nil {
-		return fmt.Errorf("failed to connect to %s: %v", host, err)
+		return fmt.Errorf("failed to connect to %s via %s: %w: %w", target.Host, address, classifyTLSError(err), err)
 	}
 
 	defer func() {
@@ -45,3 +91,100 @@ func CheckTLS(log logr.Logger, crtPEM, keyPEM []byte, host string) error {
 
 	return nil
 }
+
+// classifyTLSError returns the failure of the probe the dial error belongs to
+func classifyTLSError(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrDNSResolution
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return ErrConnectionRefused
+	}
+
+	if errors.Is(err, syscall.ENETUNREACH) || errors.Is(err, syscall.EHOSTUNREACH) {
+		return ErrNetworkUnreachable
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrConnectionTimeout
+	}
+
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &hostnameErr) {
+		return ErrHostnameMismatch
+	}
+
+	var invalidErr x509.CertificateInvalidError
+	if errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired {
+		// x509 reports both expired and not yet valid certificates as Expired
+		if invalidErr.Cert != nil && time.Now().Before(invalidErr.Cert.NotBefore) {
+			return ErrCertificateNotYetValid
+		}
+		return ErrCertificateExpired
+	}
+
+	var authorityErr x509.UnknownAuthorityError
+	if errors.As(err, &authorityErr) {
+		return ErrUnknownAuthority
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return ErrNetworkUnreachable
+	}
+
+	return ErrHandshake
+}
+
+// HostMatches reports whether the host is matched by the pattern, which may be a wildcard like *.foo.com
+func HostMatches(pattern, host string) bool {
+	pattern = strings.ToLower(pattern)
+	host = strings.ToLower(host)
+
+	if pattern == host {
+		return true
+	}
+
+	suffix, ok := strings.CutPrefix(pattern, "*.")
+	if !ok {
+		return false
+	}
+
+	// A wildcard only matches a single label
+	label, rest, found := strings.Cut(host, ".")
+	return found && label != "" && rest == suffix
+}
+
+// ParseCertificate parses the first certificate of the []byte PEM crt
+func ParseCertificate(crtPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(crtPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM certificate found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// CertificateCoversHost verifies that the subject alternative names of the []byte PEM crt cover the host
+func CertificateCoversHost(crtPEM []byte, host string) error {
+	cert, err := ParseCertificate(crtPEM)
+	if err != nil {
+		return err
+	}
+
+	return cert.VerifyHostname(host)
+}
+
+// Fingerprint returns the hex SHA-256 of the first certificate of the []byte PEM crt, or empty if it cannot be parsed
+func Fingerprint(crtPEM []byte) string {
+	cert, err := ParseCertificate(crtPEM)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// dialError wraps the error like a failed dial of the probe
+func dialError(err error) error {
+	return &net.OpError{Op: "dial", Net: "tcp", Err: &os.SyscallError{Syscall: "connect", Err: err}}
+}
+
+// verificationError wraps the error like a failed verification of the certificate in the handshake
+func verificationError(err error) error {
+	return &tls.CertificateVerificationError{Err: err}
+}
+
+var _ = Describe("TLS errors", func() {
+	DescribeTable("should classify the failure of the probe",
+		func(err, expected error) {
+			Expect(classifyTLSError(err)).To(MatchError(expected))
+		},
+		Entry("DNS resolution", &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "foo.com"}}, ErrDNSResolution),
+		Entry("connection refused", dialError(syscall.ECONNREFUSED), ErrConnectionRefused),
+		Entry("network unreachable", dialError(syscall.ENETUNREACH), ErrNetworkUnreachable),
+		Entry("host unreachable", dialError(syscall.EHOSTUNREACH), ErrNetworkUnreachable),
+		Entry("other dial failure", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route")}, ErrNetworkUnreachable),
+		Entry("timeout", &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, ErrConnectionTimeout),
+		Entry("hostname mismatch", verificationError(x509.HostnameError{Host: "foo.com"}), ErrHostnameMismatch),
+		Entry("expired certificate", verificationError(x509.CertificateInvalidError{
+			Cert: &x509.Certificate{NotBefore: time.Now().Add(-48 * time.Hour), NotAfter: time.Now().Add(-24 * time.Hour)}, Reason: x509.Expired,
+		}), ErrCertificateExpired),
+		Entry("not yet valid certificate", verificationError(x509.CertificateInvalidError{
+			Cert: &x509.Certificate{NotBefore: time.Now().Add(24 * time.Hour), NotAfter: time.Now().Add(48 * time.Hour)}, Reason: x509.Expired,
+		}), ErrCertificateNotYetValid),
+		Entry("other invalid certificate", verificationError(x509.CertificateInvalidError{Reason: x509.NotAuthorizedToSign}), ErrHandshake),
+		Entry("unknown authority", verificationError(x509.UnknownAuthorityError{}), ErrUnknownAuthority),
+		Entry("handshake failure", &net.OpError{Op: "remote error", Err: errors.New("tls: handshake failure")}, ErrHandshake),
+	)
+})